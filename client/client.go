@@ -0,0 +1,182 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	Year           = 2020
+	DefaultBaseURL = "https://adventofcode.com"
+	userAgent      = "github.com/evido/adventofcode2020"
+)
+
+var ErrNoSession = errors.New("No session cookie configured, set AOC_SESSION or \"session\" in the config file")
+
+// Config holds the settings read from the config file. Every setting can be
+// overridden through the environment: AOC_SESSION, AOC_BASE_URL and
+// AOC_CACHE_DIR.
+type Config struct {
+	Session  string `json:"session"`
+	BaseURL  string `json:"base_url"`
+	CacheDir string `json:"cache_dir"`
+}
+
+// ConfigPath returns the config file location, which is AOC_CONFIG when set
+// and aoc/config.json in the user config directory otherwise.
+func ConfigPath() (string, error) {
+	if path := os.Getenv("AOC_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "config.json"), nil
+}
+
+func LoadConfig() (Config, error) {
+	var config Config
+
+	path, err := ConfigPath()
+	if err != nil {
+		return config, err
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return config, err
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(bytes, &config); err != nil {
+			return config, fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	if session := os.Getenv("AOC_SESSION"); session != "" {
+		config.Session = session
+	}
+	if baseURL := os.Getenv("AOC_BASE_URL"); baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	if cacheDir := os.Getenv("AOC_CACHE_DIR"); cacheDir != "" {
+		config.CacheDir = cacheDir
+	}
+
+	return config, nil
+}
+
+type Client struct {
+	BaseURL    string
+	Session    string
+	CacheDir   string
+	HTTPClient *http.Client
+}
+
+func New(config Config) (*Client, error) {
+	client := &Client{
+		BaseURL:    strings.TrimSuffix(config.BaseURL, "/"),
+		Session:    config.Session,
+		CacheDir:   config.CacheDir,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if client.BaseURL == "" {
+		client.BaseURL = DefaultBaseURL
+	}
+
+	if client.CacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		client.CacheDir = filepath.Join(dir, "aoc")
+	}
+
+	return client, nil
+}
+
+func (c *Client) InputPath(day int) string {
+	return filepath.Join(c.CacheDir, fmt.Sprint(Year), fmt.Sprintf("day%d", day), "input.txt")
+}
+
+func (c *Client) CachedInput(day int) (string, bool) {
+	path := c.InputPath(day)
+	_, err := os.Stat(path)
+	return path, err == nil
+}
+
+func (c *Client) do(method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	request, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("User-Agent", userAgent)
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	return c.HTTPClient.Do(request)
+}
+
+// FetchInput returns the path of the cached input for day, downloading it
+// first when it is not cached yet or when force is set.
+func (c *Client) FetchInput(day int, force bool) (string, error) {
+	if path, ok := c.CachedInput(day); ok && !force {
+		return path, nil
+	}
+
+	response, err := c.do(http.MethodGet, fmt.Sprintf("/%d/day/%d/input", Year, day), nil, "")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	bytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Unable to fetch input for day %d: %s: %s",
+			day, response.Status, strings.TrimSpace(string(bytes)))
+	}
+
+	path := c.InputPath(day)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), "input-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(bytes); err != nil {
+		file.Close()
+		return "", err
+	}
+
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	return path, os.Rename(file.Name(), path)
+}
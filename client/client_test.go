@@ -0,0 +1,93 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := New(Config{
+		Session:  "secret",
+		BaseURL:  server.URL,
+		CacheDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestFetchInput(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests += 1
+		if r.URL.Path != "/2020/day/3/input" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			t.Errorf("missing session cookie")
+		}
+
+		w.Write([]byte("..#\n#..\n"))
+	})
+
+	for i := 0; i < 2; i += 1 {
+		path, err := client.FetchInput(3, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(bytes) != "..#\n#..\n" {
+			t.Errorf("unexpected input %q", bytes)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+
+	if _, err := client.FetchInput(3, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Errorf("expected a forced fetch to download again, got %d requests", requests)
+	}
+}
+
+func TestFetchInputError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Puzzle inputs differ by user.", http.StatusBadRequest)
+	})
+
+	if _, err := client.FetchInput(1, false); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if _, ok := client.CachedInput(1); ok {
+		t.Error("failed fetch should not be cached")
+	}
+}
+
+func TestFetchInputWithoutSession(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+	client.Session = ""
+
+	if _, err := client.FetchInput(1, false); err != ErrNoSession {
+		t.Fatalf("expected ErrNoSession, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	Incorrect     Verdict = "incorrect"
+	TooHigh       Verdict = "too high"
+	TooLow        Verdict = "too low"
+	Wait          Verdict = "wait"
+	AlreadySolved Verdict = "already solved"
+	Unknown       Verdict = "unknown"
+)
+
+func (verdict Verdict) IsWrong() bool {
+	return verdict == Incorrect || verdict == TooHigh || verdict == TooLow
+}
+
+var (
+	articlePattern  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern      = regexp.MustCompile(`<[^>]*>`)
+	leftPattern     = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	cooldownPattern = regexp.MustCompile(`[Pp]lease wait (one|\d+) minutes? before trying again`)
+)
+
+func responseText(body string) string {
+	if match := articlePattern.FindStringSubmatch(body); match != nil {
+		body = match[1]
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(body, ""))), " ")
+}
+
+// ParseResponse interprets the page returned after submitting an answer. The
+// returned duration is how long to wait before the next attempt.
+func ParseResponse(body string) (Verdict, time.Duration) {
+	text := responseText(body)
+
+	var cooldown time.Duration
+	if match := leftPattern.FindStringSubmatch(text); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		cooldown = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := cooldownPattern.FindStringSubmatch(text); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		cooldown = time.Duration(minutes) * time.Minute
+	}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		return Correct, cooldown
+	case strings.Contains(text, "answer is too high"):
+		return TooHigh, cooldown
+	case strings.Contains(text, "answer is too low"):
+		return TooLow, cooldown
+	case strings.Contains(text, "That's not the right answer"):
+		return Incorrect, cooldown
+	case strings.Contains(text, "You gave an answer too recently"):
+		return Wait, cooldown
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		return AlreadySolved, cooldown
+	default:
+		return Unknown, cooldown
+	}
+}
+
+type Attempt struct {
+	Day       int       `json:"day"`
+	Part      int       `json:"part"`
+	Answer    int64     `json:"answer"`
+	Verdict   Verdict   `json:"verdict"`
+	Time      time.Time `json:"time"`
+	WaitUntil time.Time `json:"wait_until,omitempty"`
+}
+
+// History records every submitted answer so that answers known to be wrong
+// are never submitted twice.
+type History struct {
+	path     string
+	Attempts []Attempt
+}
+
+func (c *Client) HistoryPath() string {
+	return filepath.Join(c.CacheDir, fmt.Sprint(Year), "history.json")
+}
+
+func LoadHistory(path string) (*History, error) {
+	history := &History{
+		path:     path,
+		Attempts: make([]Attempt, 0),
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bytes, &history.Attempts); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return history, nil
+}
+
+func (history *History) Save() error {
+	bytes, err := json.MarshalIndent(history.Attempts, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(history.path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(history.path, append(bytes, '\n'), 0600)
+}
+
+func (history *History) Record(attempt Attempt) {
+	history.Attempts = append(history.Attempts, attempt)
+}
+
+type RefusedError struct {
+	Reason string
+}
+
+func (e *RefusedError) Error() string {
+	return "Refusing to submit: " + e.Reason
+}
+
+// Check refuses answers that the history already rules out: answers known to
+// be wrong, answers outside the bounds of earlier too high or too low
+// answers, parts that were already solved and attempts during a cooldown.
+func (history *History) Check(day, part int, answer int64, now time.Time) error {
+	for _, attempt := range history.Attempts {
+		if attempt.Day != day || attempt.Part != part {
+			continue
+		}
+
+		switch {
+		case now.Before(attempt.WaitUntil):
+			return &RefusedError{fmt.Sprintf("cooldown until %s (%s left)",
+				attempt.WaitUntil.Format(time.RFC3339), attempt.WaitUntil.Sub(now).Round(time.Second))}
+		case attempt.Verdict == Correct && attempt.Answer == answer:
+			return &RefusedError{fmt.Sprintf("%d is already known to be correct", answer)}
+		case attempt.Verdict == Correct:
+			return &RefusedError{fmt.Sprintf("already solved with %d", attempt.Answer)}
+		case attempt.Verdict == AlreadySolved:
+			return &RefusedError{"already solved"}
+		case attempt.Verdict.IsWrong() && attempt.Answer == answer:
+			return &RefusedError{fmt.Sprintf("%d was already rejected", answer)}
+		case attempt.Verdict == TooHigh && answer >= attempt.Answer:
+			return &RefusedError{fmt.Sprintf("%d is at least %d, which is too high", answer, attempt.Answer)}
+		case attempt.Verdict == TooLow && answer <= attempt.Answer:
+			return &RefusedError{fmt.Sprintf("%d is at most %d, which is too low", answer, attempt.Answer)}
+		}
+	}
+
+	return nil
+}
+
+// Submit posts answer for one part of day without consulting any history.
+func (c *Client) Submit(day, part int, answer int64) (Verdict, time.Duration, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {strconv.FormatInt(answer, 10)},
+	}
+
+	response, err := c.do(http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", Year, day),
+		strings.NewReader(form.Encode()), "application/x-www-form-urlencoded")
+	if err != nil {
+		return Unknown, 0, err
+	}
+	defer response.Body.Close()
+
+	bytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return Unknown, 0, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return Unknown, 0, fmt.Errorf("Unable to submit answer for day %d: %s", day, response.Status)
+	}
+
+	verdict, cooldown := ParseResponse(string(bytes))
+	return verdict, cooldown, nil
+}
+
+// SubmitChecked submits answer unless history rules it out, and records the
+// attempt in history.
+func (c *Client) SubmitChecked(history *History, day, part int, answer int64) (Attempt, error) {
+	now := time.Now()
+	if err := history.Check(day, part, answer, now); err != nil {
+		return Attempt{}, err
+	}
+
+	verdict, cooldown, err := c.Submit(day, part, answer)
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	attempt := Attempt{
+		Day:     day,
+		Part:    part,
+		Answer:  answer,
+		Verdict: verdict,
+		Time:    now,
+	}
+	if cooldown > 0 {
+		attempt.WaitUntil = now.Add(cooldown)
+	}
+
+	history.Record(attempt)
+	return attempt, history.Save()
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseResponse(t *testing.T) {
+	cases := []struct {
+		body     string
+		verdict  Verdict
+		cooldown time.Duration
+	}{
+		{"<main><article><p>That's the right answer!  You are one gold star closer.</p></article></main>", Correct, 0},
+		{"<article><p>That's not the right answer; your answer is too high.  Please wait one minute before trying again.</p></article>", TooHigh, time.Minute},
+		{"<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>", TooLow, 5 * time.Minute},
+		{"<article><p>That's not the right answer.  Please wait one minute before trying again.</p></article>", Incorrect, time.Minute},
+		{"<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 12s left to wait.</p></article>", Wait, 72 * time.Second},
+		{"<article><p>You gave an answer too recently.  You have 38s left to wait.</p></article>", Wait, 38 * time.Second},
+		{"<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>", AlreadySolved, 0},
+		{"<html>Something else</html>", Unknown, 0},
+	}
+
+	for _, c := range cases {
+		verdict, cooldown := ParseResponse(c.body)
+		if verdict != c.verdict || cooldown != c.cooldown {
+			t.Errorf("%q: expected %s/%s, got %s/%s", c.body, c.verdict, c.cooldown, verdict, cooldown)
+		}
+	}
+}
+
+func TestSubmitChecked(t *testing.T) {
+	responses := []string{
+		"<article><p>That's not the right answer; your answer is too high.</p></article>",
+		"<article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again.</p></article>",
+	}
+	submitted := make([]string, 0)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2020/day/1/answer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		submitted = append(submitted, r.FormValue("level")+":"+r.FormValue("answer"))
+		w.Write([]byte(responses[len(submitted)-1]))
+	})
+
+	historyPath := filepath.Join(t.TempDir(), "history.json")
+	history, err := LoadHistory(historyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempt, err := client.SubmitChecked(history, 1, 2, 100)
+	if err != nil || attempt.Verdict != TooHigh {
+		t.Fatalf("expected too high, got %v, %v", attempt.Verdict, err)
+	}
+
+	for _, answer := range []int64{100, 150} {
+		if _, err := client.SubmitChecked(history, 1, 2, answer); err == nil {
+			t.Errorf("expected %d to be refused", answer)
+		}
+	}
+
+	attempt, err = client.SubmitChecked(history, 1, 2, 50)
+	if err != nil || attempt.Verdict != TooLow {
+		t.Fatalf("expected too low, got %v, %v", attempt.Verdict, err)
+	}
+
+	if _, err := client.SubmitChecked(history, 1, 2, 75); err == nil {
+		t.Error("expected submission during cooldown to be refused")
+	}
+
+	if len(submitted) != 2 || submitted[0] != "2:100" || submitted[1] != "2:50" {
+		t.Errorf("unexpected submissions %v", submitted)
+	}
+
+	reloaded, err := LoadHistory(historyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reloaded.Attempts) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %d", len(reloaded.Attempts))
+	}
+
+	later := reloaded.Attempts[1].WaitUntil.Add(time.Second)
+	if err := reloaded.Check(1, 2, 75, later); err != nil {
+		t.Errorf("expected 75 to be allowed after the cooldown, got %s", err)
+	}
+}
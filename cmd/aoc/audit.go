@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/evido/adventofcode2020/day2"
+)
+
+var auditFormats = []string{"text", "json"}
+
+func writeAuditEntry(w io.Writer, entry day2.AuditEntry) {
+	status := "valid"
+	if !entry.Verdict.Valid {
+		status = "INVALID"
+	}
+	fmt.Fprintf(w, "%6d  %-7s  %s: %s  (%s)\n", entry.Index, status, entry.Policy, entry.Password, entry.Verdict)
+
+	if entry.Repair != nil {
+		edits := make([]string, len(entry.Repair.Edits))
+		for ix, edit := range entry.Repair.Edits {
+			edits[ix] = edit.String()
+		}
+		fmt.Fprintf(w, "%6s  %-7s  %s: %s  (%s)\n", "", "repair", entry.Policy, entry.Repair.Password, strings.Join(edits, ", "))
+	}
+}
+
+func writeAuditTotals(w io.Writer, report *day2.AuditReport) {
+	fmt.Fprintf(w, "\nValid: %d, invalid: %d\n", report.Valid, report.Invalid)
+	for _, reason := range report.Reasons {
+		fmt.Fprintf(w, "  %6d  %s\n", reason.Count, reason.Reason)
+	}
+}
+
+// audit validates the password database as a stream, so that text reports
+// are written while reading and only JSON reports hold every entry in memory.
+func audit(args []string) {
+	flags := flag.NewFlagSet("audit", flag.ExitOnError)
+	inputFile := flags.String("input", "", "password database of day 2, - for standard input (default day2/input.txt or the input cached by aoc fetch)")
+	policy := flags.String("policy", "count", "policy to audit, one of "+strings.Join(day2.Policies(), ", ")+" or a combination")
+	output := flags.String("output", "text", "report format: "+strings.Join(auditFormats, ", "))
+	invalidOnly := flags.Bool("invalid", false, "only list the entries that fail the policy")
+	repair := flags.Bool("repair", false, "suggest the fewest edits that make each password satisfy both the count and the positions policy")
+	graphemes := flags.Bool("graphemes", false, "count grapheme clusters rather than runes when repairing")
+	workers := flags.Int("workers", 0, "number of entries validated in parallel (default GOMAXPROCS)")
+	flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		usage()
+	}
+
+	if err := checkFormat(*output, auditFormats); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	rule, err := day2.ParsePolicy(*policy)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	units := day2.Runes
+	if *graphemes {
+		units = day2.Graphemes
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := day2.NewAuditReport()
+	err = day2.ValidateStream(ctx, openInput(2, *inputFile), rule, day2.StreamOptions{Workers: *workers},
+		func(result day2.StreamResult) error {
+			report.Tally(result.Verdict)
+			if *invalidOnly && result.Verdict.Valid {
+				return nil
+			}
+
+			entry := day2.AuditEntry{
+				Index:    result.Index,
+				Line:     result.Line,
+				Policy:   result.Entry.Policy.String(),
+				Password: result.Entry.Password,
+				Verdict:  result.Verdict,
+			}
+			if *repair {
+				entry.Repair = day2.SuggestRepair(result.Entry, units)
+			}
+
+			if *output == "json" {
+				report.Entries = append(report.Entries, entry)
+			} else {
+				writeAuditEntry(os.Stdout, entry)
+			}
+			return nil
+		})
+
+	if err != nil {
+		renderError(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *output == "json" {
+		bytes, err := json.MarshalIndent(report, "", "\t")
+		if err != nil {
+			log.Fatalf("%s\n", err)
+		}
+		fmt.Printf("%s\n", bytes)
+	} else {
+		writeAuditTotals(os.Stdout, report)
+	}
+}
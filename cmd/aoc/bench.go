@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/evido/adventofcode2020/solver"
+)
+
+// benchInput returns the input to benchmark day against, with the parameters
+// the example input is solved with when falling back to it.
+func benchInput(day int) (string, solver.Params) {
+	if _, err := os.Stat(inputPath(day)); err == nil {
+		return inputPath(day), nil
+	}
+
+	dir := fmt.Sprintf("day%d", day)
+	expectations, err := solver.LoadExpectations(filepath.Join(dir, "expected.json"))
+	if err == nil {
+		for _, expectation := range expectations {
+			if expectation.Input == "test_input.txt" {
+				return filepath.Join(dir, expectation.Input), expectation.Params
+			}
+		}
+	}
+	return filepath.Join(dir, "test_input.txt"), nil
+}
+
+func loadBaseline(filename string) map[string]solver.Measurement {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Unable to read baseline: %s\n", err)
+	}
+
+	measurements := make([]solver.Measurement, 0)
+	if err := json.Unmarshal(bytes, &measurements); err != nil {
+		log.Fatalf("Unable to read baseline: %s: %s\n", filename, err)
+	}
+
+	baseline := make(map[string]solver.Measurement)
+	for _, measurement := range measurements {
+		baseline[fmt.Sprintf("%d/%s", measurement.Day, measurement.Phase)] = measurement
+	}
+	return baseline
+}
+
+func saveBaseline(filename string, measurements []solver.Measurement) {
+	bytes, err := json.MarshalIndent(measurements, "", "\t")
+	if err != nil {
+		log.Fatalf("Unable to save baseline: %s\n", err)
+	}
+
+	if err := ioutil.WriteFile(filename, append(bytes, '\n'), 0644); err != nil {
+		log.Fatalf("Unable to save baseline: %s\n", err)
+	}
+}
+
+func bench(args []string) {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	inputFile := flags.String("input", "", "puzzle input, only when benchmarking a single day (default day<N>/input.txt, falling back to the example input)")
+	saveFile := flags.String("save", "", "save the measurements as a baseline to this file")
+	baselineFile := flags.String("baseline", "", "compare the measurements against a saved baseline")
+	threshold := flags.Float64("threshold", 10, "percentage by which ns/op or allocs/op may exceed the baseline")
+	flags.Parse(args)
+
+	days := solver.Days()
+	if flags.NArg() > 0 {
+		days = make([]int, 0, flags.NArg())
+		for _, arg := range flags.Args() {
+			days = append(days, parseNumber("day", arg))
+		}
+	}
+
+	if *inputFile != "" && len(days) != 1 {
+		log.Fatalf("-input requires a single day\n")
+	}
+
+	var baseline map[string]solver.Measurement
+	if *baselineFile != "" {
+		baseline = loadBaseline(*baselineFile)
+	}
+
+	regressions := 0
+	measurements := make([]solver.Measurement, 0)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(writer, "Day\tPhase\tns/op\tB/op\tallocs/op\tpeak heap\t")
+	if baseline != nil {
+		fmt.Fprintf(writer, "vs baseline\t")
+	}
+	fmt.Fprintf(writer, "\n")
+
+	for _, day := range days {
+		filename, params := *inputFile, solver.Params(nil)
+		if filename == "" {
+			filename, params = benchInput(day)
+		}
+
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			log.Fatalf("Unable to read input: %s\n", err)
+		}
+
+		for _, phase := range solver.Phases {
+			measurement, err := solver.Measure(day, phase, data, params)
+			if err != nil {
+				fmt.Fprintf(writer, "%d\t%s\t%s\t\t\t\t\n", day, phase, err)
+				continue
+			}
+			measurements = append(measurements, measurement)
+
+			fmt.Fprintf(writer, "%d\t%s\t%.0f\t%d\t%d\t%d\t", day, phase,
+				measurement.NsPerOp, measurement.BytesPerOp, measurement.AllocsPerOp, measurement.PeakHeap)
+
+			if baseline != nil {
+				previous, ok := baseline[fmt.Sprintf("%d/%s", day, phase)]
+				if !ok {
+					fmt.Fprintf(writer, "new\t")
+				} else {
+					change := 100 * (measurement.NsPerOp - previous.NsPerOp) / previous.NsPerOp
+					regressed := change > *threshold ||
+						float64(measurement.AllocsPerOp) > float64(previous.AllocsPerOp)*(1+*threshold/100)
+					if regressed {
+						regressions += 1
+						fmt.Fprintf(writer, "%+.1f%% REGRESSION\t", change)
+					} else {
+						fmt.Fprintf(writer, "%+.1f%%\t", change)
+					}
+				}
+			}
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+	writer.Flush()
+
+	if *saveFile != "" {
+		saveBaseline(*saveFile, measurements)
+	}
+
+	if regressions > 0 {
+		fmt.Fprintf(os.Stderr, "%d regressions against %s\n", regressions, *baselineFile)
+		os.Exit(1)
+	}
+}
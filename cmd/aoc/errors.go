@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+func caretIndent(text string, column int) string {
+	if column > len(text)+1 {
+		column = len(text) + 1
+	}
+
+	var indent strings.Builder
+	for _, c := range text[:column-1] {
+		if c == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+	return indent.String()
+}
+
+// renderError prints err, showing the offending line of every parse error.
+// Errors that wrap several errors, such as the rejected records of a report,
+// are rendered one by one before their own message.
+func renderError(w io.Writer, err error) {
+	if multiple, ok := err.(interface{ Errors() []error }); ok {
+		for _, e := range multiple.Errors() {
+			renderError(w, e)
+		}
+	}
+
+	fmt.Fprintf(w, "%s\n", err)
+
+	var parseError *input.ParseError
+	if !errors.As(err, &parseError) || parseError.Line == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%5d | %s\n", parseError.Line, parseError.Text)
+	if parseError.Column > 0 {
+		fmt.Fprintf(w, "      | %s^\n", caretIndent(parseError.Text, parseError.Column))
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/evido/adventofcode2020/client"
+)
+
+func newClient() *client.Client {
+	config, err := client.LoadConfig()
+	if err != nil {
+		log.Fatalf("Unable to read config: %s\n", err)
+	}
+
+	c, err := client.New(config)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+	return c
+}
+
+// inputPath finds the puzzle input for day, preferring day<N>/input.txt in
+// the working directory over the input cached by aoc fetch.
+func inputPath(day int) string {
+	local := fmt.Sprintf("day%d/input.txt", day)
+	if _, err := os.Stat(local); err == nil {
+		return local
+	}
+
+	config, err := client.LoadConfig()
+	if err != nil {
+		return local
+	}
+
+	c, err := client.New(config)
+	if err != nil {
+		return local
+	}
+
+	if cached, ok := c.CachedInput(day); ok {
+		return cached
+	}
+	return local
+}
+
+func fetch(args []string) {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	force := flags.Bool("force", false, "download the input again even when it is cached")
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		usage()
+	}
+
+	c := newClient()
+	for _, arg := range flags.Args() {
+		day := parseNumber("day", arg)
+		path, err := c.FetchInput(day, *force)
+		if err != nil {
+			log.Fatalf("%s\n", err)
+		}
+		fmt.Printf("Day %d: %s\n", day, path)
+	}
+}
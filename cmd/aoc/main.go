@@ -0,0 +1,185 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	_ "github.com/evido/adventofcode2020/days"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "  aoc list\n")
+	fmt.Fprintf(os.Stderr, "  aoc fetch [-force] <day>...\n")
+	fmt.Fprintf(os.Stderr, "  aoc run [-input file] [-output text|json|csv] [-config file] [-p name=value]... <day> [part]\n")
+	fmt.Fprintf(os.Stderr, "  aoc params <day>\n")
+	fmt.Fprintf(os.Stderr, "  aoc submit [-input file] <day> <part>\n")
+	fmt.Fprintf(os.Stderr, "  aoc verify [-answers file] [-tests]\n")
+	fmt.Fprintf(os.Stderr, "  aoc audit [-input file] [-policy expression] [-output text|json] [-invalid] [-repair [-graphemes]] [-workers n]\n")
+	fmt.Fprintf(os.Stderr, "  aoc render [-input file] [-slopes \"dx,dy ...\"] [-torus] [-colour] [-text file | -png file [-cell pixels]]\n")
+	fmt.Fprintf(os.Stderr, "  aoc route [-input file] [-moves set] [-tree-free] [-algorithm bfs|dijkstra|astar] [-output text|json] [-colour]\n")
+	fmt.Fprintf(os.Stderr, "  aoc bench [-input file] [-save file] [-baseline file] [-threshold percent] [day...]\n")
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "list":
+		list()
+	case "fetch":
+		fetch(os.Args[2:])
+	case "run":
+		run(os.Args[2:])
+	case "params":
+		params(os.Args[2:])
+	case "submit":
+		submit(os.Args[2:])
+	case "verify":
+		verify(os.Args[2:])
+	case "bench":
+		bench(os.Args[2:])
+	case "audit":
+		audit(os.Args[2:])
+	case "render":
+		render(os.Args[2:])
+	case "route":
+		route(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func list() {
+	for _, day := range solver.Days() {
+		parts := make([]string, 0, solver.Parts)
+		for part := 1; part <= solver.Parts; part += 1 {
+			parts = append(parts, strconv.Itoa(part))
+		}
+		fmt.Printf("Day %d: parts %s\n", day, strings.Join(parts, ", "))
+	}
+}
+
+func parseNumber(name, value string) int {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %s\n", name, value)
+	}
+	return number
+}
+
+func parsePart(day int, value string) int {
+	part := parseNumber("part", value)
+	if part < 1 || part > solver.Parts {
+		log.Fatalf("Day %d has no part %d\n", day, part)
+	}
+	return part
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (r namedReader) Name() string {
+	return r.name
+}
+
+// openInput opens inputFile, which is standard input when it is "-", or the
+// input found through inputPath when it is empty. The file stays open until
+// the command exits.
+func openInput(day int, inputFile string) io.Reader {
+	if inputFile == "-" {
+		return namedReader{os.Stdin, "<stdin>"}
+	}
+
+	explicitInput := inputFile != ""
+	if !explicitInput {
+		inputFile = inputPath(day)
+	}
+
+	file, err := os.Open(inputFile)
+	if os.IsNotExist(err) && !explicitInput {
+		log.Fatalf("No input for day %d, download it with: aoc fetch %d\n", day, day)
+	}
+	if err != nil {
+		log.Fatalf("Unable to open input: %s\n", err)
+	}
+	return file
+}
+
+// loadSolver configures the solver for day with params and parses its input,
+// which is read through openInput.
+func loadSolver(day int, inputFile string, params solver.Params) solver.Solver {
+	s, err := solver.New(day)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	if err := solver.Configure(s, params); err != nil {
+		log.Fatalf("Day %d: %s\n", day, err)
+	}
+
+	if err := s.Parse(openInput(day, inputFile)); err != nil {
+		renderError(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	return s
+}
+
+func run(args []string) {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	inputFile := flags.String("input", "", "puzzle input, - for standard input (default day<N>/input.txt or the input cached by aoc fetch)")
+	output := flags.String("output", "text", "result format: "+strings.Join(outputFormats, ", "))
+	paramFlags := addParamFlags(flags)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		usage()
+	}
+
+	if err := checkFormat(*output, outputFormats); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	day := parseNumber("day", flags.Arg(0))
+
+	parts := make([]int, 0)
+	if flags.NArg() == 2 {
+		parts = append(parts, parsePart(day, flags.Arg(1)))
+	} else {
+		for part := 1; part <= solver.Parts; part += 1 {
+			parts = append(parts, part)
+		}
+	}
+
+	s := loadSolver(day, *inputFile, paramFlags.resolve(day))
+
+	failed := false
+	results := make([]solver.Result, 0, len(parts))
+	for _, part := range parts {
+		result := solver.Run(s, day, part)
+		failed = failed || result.Err != nil
+		results = append(results, result)
+	}
+
+	if err := writeResults(os.Stdout, *output, results); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
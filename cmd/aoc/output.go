@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/evido/adventofcode2020/solver"
+)
+
+var outputFormats = []string{"text", "json", "csv"}
+
+// checkFormat returns an error unless format is one of formats.
+func checkFormat(format string, formats []string) error {
+	for _, known := range formats {
+		if format == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown output format %q, expected one of %s", format, strings.Join(formats, ", "))
+}
+
+func sortedKeys(details solver.Details) []string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeText(w io.Writer, results []solver.Result) error {
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(w, "Day %d, part %d: %s\n", result.Day, result.Part, result.Err)
+			continue
+		}
+
+		fmt.Fprintf(w, "Day %d, part %d: %d (%s)\n", result.Day, result.Part, result.Answer, result.Duration)
+		for _, key := range sortedKeys(result.Details) {
+			fmt.Fprintf(w, "    %s: %v\n", key, result.Details[key])
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, results []solver.Result) error {
+	bytes, err := json.MarshalIndent(results, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", bytes)
+	return err
+}
+
+func writeCSV(w io.Writer, results []solver.Result) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"day", "part", "answer", "duration_ns", "error", "details"})
+
+	for _, result := range results {
+		details := make([]string, 0, len(result.Details))
+		for _, key := range sortedKeys(result.Details) {
+			details = append(details, fmt.Sprintf("%s=%v", key, result.Details[key]))
+		}
+
+		writer.Write([]string{
+			strconv.Itoa(result.Day),
+			strconv.Itoa(result.Part),
+			strconv.FormatInt(int64(result.Answer), 10),
+			strconv.FormatInt(result.Duration.Nanoseconds(), 10),
+			result.Error,
+			strings.Join(details, ";"),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeResults(w io.Writer, format string, results []solver.Result) error {
+	switch format {
+	case "text":
+		return writeText(w, results)
+	case "json":
+		return writeJSON(w, results)
+	case "csv":
+		return writeCSV(w, results)
+	default:
+		return checkFormat(format, outputFormats)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type paramOverrides solver.Params
+
+func (overrides paramOverrides) String() string {
+	parts := make([]string, 0, len(overrides))
+	for name, value := range overrides {
+		parts = append(parts, name+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (overrides paramOverrides) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("parameter should be formatted as <name>=<value>: %s", value)
+	}
+
+	overrides[parts[0]] = parts[1]
+	return nil
+}
+
+type paramFlags struct {
+	configFile string
+	overrides  paramOverrides
+}
+
+func addParamFlags(flags *flag.FlagSet) *paramFlags {
+	params := &paramFlags{
+		overrides: make(paramOverrides),
+	}
+
+	flags.StringVar(&params.configFile, "config", "", "parameter file (default day<N>/params.json when present)")
+	flags.Var(params.overrides, "p", "set a parameter as <name>=<value>, may be repeated")
+	return params
+}
+
+// dayParamsFile returns day<N>/params.json, or "" when day has no
+// parameter file.
+func dayParamsFile(day int) string {
+	configFile := fmt.Sprintf("day%d/params.json", day)
+	if _, err := os.Stat(configFile); err != nil {
+		return ""
+	}
+	return configFile
+}
+
+// resolve merges the parameter file of day with the overrides from the
+// command line.
+func (flags *paramFlags) resolve(day int) solver.Params {
+	configFile := flags.configFile
+	if configFile == "" {
+		configFile = dayParamsFile(day)
+	}
+
+	params := make(solver.Params)
+	if configFile != "" {
+		loaded, err := solver.LoadParams(configFile)
+		if err != nil {
+			log.Fatalf("Unable to read parameters: %s\n", err)
+		}
+		params = loaded
+	}
+
+	for name, value := range flags.overrides {
+		params[name] = value
+	}
+	return params
+}
+
+func params(args []string) {
+	if len(args) != 1 {
+		usage()
+	}
+
+	day := parseNumber("day", args[0])
+	s, err := solver.New(day)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	set := solver.ParamSet(s)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Name\tDefault\tDescription\n")
+	set.VisitAll(func(f *flag.Flag) {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", f.Name, f.DefValue, f.Usage)
+	})
+	writer.Flush()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/evido/adventofcode2020/day3"
+)
+
+func render(args []string) {
+	slopes := day3.Slopes{{Dx: 3, Dy: 1}}
+
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	inputFile := flags.String("input", "", "field of day 3, - for standard input (default day3/input.txt or the input cached by aoc fetch)")
+	flags.Var(&slopes, "slopes", "slopes to draw the paths of, such as \"3,1 1,2\"")
+	torus := flags.Bool("torus", false, "wrap the field vertically too and follow each slope until it is back at the start")
+	colour := flags.Bool("colour", false, "colour the path of every slope with ANSI escapes")
+	textFile := flags.String("text", "", "write the text rendering to this file instead of standard output")
+	pngFile := flags.String("png", "", "write a PNG image to this file instead of text")
+	cellSize := flags.Int("cell", 8, "size of a cell in the PNG image, in pixels")
+	flags.Parse(args)
+
+	if flags.NArg() != 0 || *cellSize < 1 {
+		usage()
+	}
+
+	field, err := day3.ReadField(openInput(3, *inputFile))
+	if err != nil {
+		renderError(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	canvas, err := field.Canvas(slopes, *torus)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	out := os.Stdout
+	filename := *textFile
+	if *pngFile != "" {
+		filename = *pngFile
+	}
+	if filename != "" {
+		file, err := os.Create(filename)
+		if err != nil {
+			log.Fatalf("%s\n", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if *pngFile != "" {
+		err = canvas.WritePNG(out, *cellSize)
+	} else {
+		err = canvas.WriteText(out, *colour)
+	}
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/evido/adventofcode2020/day3"
+)
+
+func route(args []string) {
+	algorithms := make([]string, len(day3.Algorithms))
+	for ix, algorithm := range day3.Algorithms {
+		algorithms[ix] = string(algorithm)
+	}
+
+	flags := flag.NewFlagSet("route", flag.ExitOnError)
+	inputFile := flags.String("input", "", "field of day 3, - for standard input (default day3/input.txt or the input cached by aoc fetch)")
+	movesText := flags.String("moves", "sideways", "moves to route with, one of "+strings.Join(day3.MoveSetNames(), ", ")+" or a list of <dx>,<dy>")
+	treeFree := flags.Bool("tree-free", false, "only route through open cells")
+	algorithm := flags.String("algorithm", string(day3.AStar), "search algorithm: "+strings.Join(algorithms, ", "))
+	output := flags.String("output", "text", "result format: text, json")
+	colour := flags.Bool("colour", false, "colour the route with ANSI escapes")
+	flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		usage()
+	}
+
+	moves, err := day3.ParseMoves(*movesText)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	field, err := day3.ReadField(openInput(3, *inputFile))
+	if err != nil {
+		renderError(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	found, err := day3.FindRoute(field, day3.RouteOptions{
+		Moves:     moves,
+		TreeFree:  *treeFree,
+		Algorithm: day3.Algorithm(*algorithm),
+	})
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	switch *output {
+	case "text":
+		fmt.Printf("Trees: %d, steps: %d\n", found.Trees, found.Steps)
+		if err := field.RouteCanvas(found).WriteText(os.Stdout, *colour); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+	case "json":
+		bytes, err := json.MarshalIndent(found, "", "\t")
+		if err != nil {
+			log.Fatalf("%s\n", err)
+		}
+		fmt.Printf("%s\n", bytes)
+	default:
+		log.Fatalf("Unknown output format %q, expected one of text, json\n", *output)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/evido/adventofcode2020/client"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func submit(args []string) {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	inputFile := flags.String("input", "", "puzzle input, - for standard input (default day<N>/input.txt or the input cached by aoc fetch)")
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		usage()
+	}
+
+	day := parseNumber("day", flags.Arg(0))
+	part := parsePart(day, flags.Arg(1))
+
+	// Answers are always computed with the default parameters, a variant
+	// answer would be rejected and then refused forever by the history.
+	if configFile := dayParamsFile(day); configFile != "" {
+		fmt.Fprintf(os.Stderr, "Ignoring %s, answers are submitted with the default parameters\n", configFile)
+	}
+	answer, err := solver.Solve(loadSolver(day, *inputFile, solver.Params{}), part)
+	if err != nil {
+		log.Fatalf("Day %d, part %d: %s\n", day, part, err)
+	}
+
+	c := newClient()
+	history, err := client.LoadHistory(c.HistoryPath())
+	if err != nil {
+		log.Fatalf("Unable to read answer history: %s\n", err)
+	}
+
+	attempt, err := c.SubmitChecked(history, day, part, int64(answer))
+	if err != nil {
+		log.Fatalf("Day %d, part %d: %s\n", day, part, err)
+	}
+
+	fmt.Printf("Day %d, part %d: %d is %s\n", day, part, answer, attempt.Verdict)
+	if !attempt.WaitUntil.IsZero() {
+		fmt.Printf("Next attempt possible in %s\n", time.Until(attempt.WaitUntil).Round(time.Second))
+	}
+}
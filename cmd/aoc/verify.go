@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func loadAnswers(filename string) []solver.Expectation {
+	expectations, err := solver.LoadExpectations(filename)
+	if err != nil {
+		log.Fatalf("Unable to read answers: %s\n", err)
+	}
+
+	for ix := range expectations {
+		if expectations[ix].Input == "" {
+			expectations[ix].Input = inputPath(expectations[ix].Day)
+		}
+	}
+
+	return expectations
+}
+
+func loadTestExpectations() []solver.Expectation {
+	all := make([]solver.Expectation, 0)
+	for _, day := range solver.Days() {
+		dir := fmt.Sprintf("day%d", day)
+		expectations, err := solver.LoadExpectations(filepath.Join(dir, "expected.json"))
+		if err != nil {
+			log.Fatalf("Unable to read expected answers: %s\n", err)
+		}
+
+		for _, expectation := range expectations {
+			expectation.Day = day
+			expectation.Input = filepath.Join(dir, expectation.Input)
+			all = append(all, expectation)
+		}
+	}
+	return all
+}
+
+func verify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	answersFile := flags.String("answers", "answers.json", "known answers for your puzzle inputs")
+	tests := flags.Bool("tests", false, "verify the example inputs shipped with each day instead")
+	flags.Parse(args)
+
+	var expectations []solver.Expectation
+	if *tests {
+		expectations = loadTestExpectations()
+	} else {
+		expectations = loadAnswers(*answersFile)
+	}
+
+	failures := make([]solver.Check, 0)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Day\tInput\tPart 1\tPart 2\n")
+	for _, expectation := range expectations {
+		fmt.Fprintf(writer, "%d\t%s", expectation.Day, expectation.Input)
+		for _, check := range solver.Verify(expectation.Day, expectation.Input, expectation) {
+			fmt.Fprintf(writer, "\t%s", check.Status)
+			if check.Status == solver.FAIL || check.Status == solver.ERROR {
+				failures = append(failures, check)
+			}
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+	writer.Flush()
+
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "%s\n", failure)
+	}
+
+	if len(failures) > 0 {
+		os.Exit(1)
+	}
+}
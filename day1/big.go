@@ -0,0 +1,108 @@
+package day1
+
+import (
+	"math/big"
+	"sort"
+)
+
+// BigSolution is a Solution for arbitrary-precision amounts.
+type BigSolution struct {
+	Terms   []*big.Int
+	Indices []int
+}
+
+func (solution BigSolution) Product() *big.Int {
+	product := big.NewInt(1)
+	for _, term := range solution.Terms {
+		product.Mul(product, term)
+	}
+	return product
+}
+
+type bigEntry struct {
+	value *big.Int
+	index int
+}
+
+// bigKSum is kSum for arbitrary-precision amounts, stopping at the first
+// combination found.
+func bigKSum(entries []bigEntry, goal *big.Int, terms int, chosen []bigEntry) []bigEntry {
+	if len(entries) < terms {
+		return nil
+	}
+
+	switch terms {
+	case 1:
+		ix := sort.Search(len(entries), func(i int) bool {
+			return entries[i].value.Cmp(goal) >= 0
+		})
+		if ix < len(entries) && entries[ix].value.Cmp(goal) == 0 {
+			return append(chosen, entries[ix])
+		}
+		return nil
+
+	case 2:
+		sum := new(big.Int)
+		lower := 0
+		upper := len(entries) - 1
+		for lower < upper {
+			switch sum.Add(entries[lower].value, entries[upper].value).Cmp(goal) {
+			case -1:
+				lower += 1
+			case 1:
+				upper -= 1
+			default:
+				return append(chosen, entries[lower], entries[upper])
+			}
+		}
+		return nil
+
+	default:
+		share := new(big.Int)
+		count := big.NewInt(int64(terms))
+		for ix := 0; ix <= len(entries)-terms; ix += 1 {
+			if share.Mul(entries[ix].value, count).Cmp(goal) > 0 {
+				break
+			}
+			if ix > 0 && entries[ix].value.Cmp(entries[ix-1].value) == 0 {
+				continue
+			}
+
+			rest := new(big.Int).Sub(goal, entries[ix].value)
+			if found := bigKSum(entries[ix+1:], rest, terms-1, append(chosen, entries[ix])); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+}
+
+// FindBigSum is FindSum for arbitrary-precision amounts.
+func FindBigSum(amounts []*big.Int, goal, terms int) (BigSolution, error) {
+	if err := checkTerms(terms); err != nil {
+		return BigSolution{}, err
+	}
+
+	entries := make([]bigEntry, len(amounts))
+	for ix, amount := range amounts {
+		entries[ix] = bigEntry{value: amount, index: ix}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].value.Cmp(entries[j].value) < 0
+	})
+
+	found := bigKSum(entries, big.NewInt(int64(goal)), terms, nil)
+	if found == nil {
+		return BigSolution{}, &NoSolutionError{Goal: goal, Terms: terms}
+	}
+
+	solution := BigSolution{
+		Terms:   make([]*big.Int, len(found)),
+		Indices: make([]int, len(found)),
+	}
+	for ix, e := range found {
+		solution.Terms[ix] = e.value
+		solution.Indices[ix] = e.index
+	}
+	return solution, nil
+}
@@ -0,0 +1,40 @@
+package day1
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestFindBigSum(t *testing.T) {
+	report, err := ReadReport(strings.NewReader("1180591620717411303424\n7\n-1180591620717411301404\n1010\n"), ReportOptions{Big: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.BigAmounts) != 4 || report.Amounts != nil {
+		t.Fatalf("expected 4 arbitrary-precision amounts, got %v and %v", report.BigAmounts, report.Amounts)
+	}
+
+	solution, err := FindBigSum(report.BigAmounts, 2020, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solution.Indices[0] != 2 || solution.Indices[1] != 0 {
+		t.Errorf("expected indices [2 0], got %v", solution.Indices)
+	}
+
+	expected, _ := new(big.Int).SetString("-1393796574908163943961187318191351761207296", 10)
+	if product := solution.Product(); product.Cmp(expected) != 0 {
+		t.Errorf("expected %s, got %s", expected, product)
+	}
+
+	if solution, err := FindBigSum(report.BigAmounts, 2027, 3); err != nil || len(solution.Terms) != 3 {
+		t.Errorf("expected three terms, got %v (%v)", solution.Terms, err)
+	}
+
+	var noSolution *NoSolutionError
+	if _, err := FindBigSum(report.BigAmounts, 2020, 3); !errors.As(err, &noSolution) {
+		t.Errorf("expected no solution, got %v", err)
+	}
+}
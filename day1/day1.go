@@ -0,0 +1,212 @@
+package day1
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/evido/adventofcode2020/solver"
+)
+
+// ReadExpenseReport reads one expense per line, skipping the lines that do
+// not hold a valid amount. Use ReadReport to find out which lines those are.
+func ReadExpenseReport(r io.Reader) ([]int, error) {
+	report, err := ReadReport(r, ReportOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return report.Expenses(), nil
+}
+
+// FindProduct returns the product of the first terms expenses found that sum
+// to goal, or -1 when there are none.
+func FindProduct(goal, terms int, expenses []int) int {
+	solution, err := FindSum(expenses, goal, terms)
+	if err != nil {
+		return -1
+	}
+
+	product, err := solution.Product()
+	if err != nil {
+		return -1
+	}
+	return product
+}
+
+// externalSum is the outcome of searching an externally sorted report for a
+// number of terms.
+type externalSum struct {
+	solution Solution
+	err      error
+}
+
+type Solver struct {
+	expenses   []int
+	goal       int
+	part1Terms int
+	part2Terms int
+	bounded    bool
+	strict     bool
+	column     int
+	comma      string
+	header     bool
+	big        bool
+	runSize    int
+	report     *Report
+	external   map[int]externalSum
+	details    map[int]solver.Details
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.goal, "goal", 2020, "sum the expenses should add up to")
+	params.IntVar(&s.part1Terms, "part1-terms", 2, "number of expenses to combine in part 1, 0 for any number")
+	params.IntVar(&s.part2Terms, "part2-terms", 3, "number of expenses to combine in part 2, 0 for any number")
+	params.BoolVar(&s.bounded, "bounded", false, "find subsets of any size using one bit per sum up to the goal")
+	params.BoolVar(&s.strict, "strict", false, "fail on lines that do not hold a valid amount instead of skipping them")
+	params.IntVar(&s.column, "column", 0, "column holding the amount in comma separated records, 0 for one amount per line")
+	params.StringVar(&s.comma, "comma", ",", "separator of the columns")
+	params.BoolVar(&s.header, "header", false, "skip the first line")
+	params.BoolVar(&s.big, "big", false, "read the amounts as arbitrary-precision integers")
+	params.IntVar(&s.runSize, "run-size", 0, "sort the report in temporary files, this many amounts at a time, instead of in memory")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	if s.column < 0 {
+		return fmt.Errorf("Invalid column: %d", s.column)
+	}
+
+	comma := []rune(s.comma)
+	if len(comma) != 1 {
+		return fmt.Errorf("The separator should be a single character: %q", s.comma)
+	}
+
+	options := ReportOptions{Column: s.column, Comma: comma[0], Header: s.header, Big: s.big}
+	if s.runSize > 0 {
+		return s.sortExternal(r, options)
+	}
+
+	report, err := ReadReport(r, options)
+	if err != nil {
+		return err
+	}
+
+	if s.strict {
+		if err := report.Err(); err != nil {
+			return err
+		}
+	}
+
+	s.report = report
+	s.expenses = report.Expenses()
+	return nil
+}
+
+// sortExternal sorts the report into a temporary file and searches it for
+// the terms of both parts right away, so that the file is closed again
+// before Parse returns.
+func (s *Solver) sortExternal(r io.Reader, options ReportOptions) error {
+	sorted, err := SortExternal(r, ExternalOptions{Report: options, RunSize: s.runSize})
+	if err != nil {
+		return err
+	}
+	defer sorted.Close()
+
+	s.external = make(map[int]externalSum)
+	for _, terms := range []int{s.part1Terms, s.part2Terms} {
+		if _, ok := s.external[terms]; ok || terms == 0 {
+			continue
+		}
+
+		solution, err := sorted.FindSum(s.goal, terms)
+		s.external[terms] = externalSum{solution, err}
+	}
+	return nil
+}
+
+func (s *Solver) find(terms int) (Solution, error) {
+	switch {
+	case s.external != nil && terms == 0:
+		return Solution{}, errors.New("Subsets of any size cannot be found in an externally sorted report")
+	case s.external != nil:
+		sum := s.external[terms]
+		return sum.solution, sum.err
+	case terms != 0:
+		return FindSum(s.expenses, s.goal, terms)
+	case s.bounded:
+		return FindSubsetBounded(s.expenses, s.goal)
+	default:
+		return FindSubset(s.expenses, s.goal)
+	}
+}
+
+func (s *Solver) describe(part int, terms interface{}, indices []int) {
+	details := solver.Details{
+		"terms":   terms,
+		"indices": indices,
+	}
+
+	if s.report != nil {
+		lines := make([]int, len(indices))
+		for ix, index := range indices {
+			lines[ix] = s.report.Lines[index]
+		}
+
+		details["lines"] = lines
+		details["report"] = s.report.Summary()
+	}
+
+	s.details[part] = details
+}
+
+func (s *Solver) solveBig(part, terms int) (solver.Answer, error) {
+	if terms == 0 {
+		return 0, errors.New("Subsets of any size cannot be found among arbitrary-precision amounts")
+	}
+
+	solution, err := FindBigSum(s.report.BigAmounts, s.goal, terms)
+	if err != nil {
+		return 0, err
+	}
+	s.describe(part, solution.Terms, solution.Indices)
+
+	product := solution.Product()
+	if !product.IsInt64() {
+		return 0, fmt.Errorf("Product %s does not fit in an answer", product)
+	}
+	return solver.Answer(product.Int64()), nil
+}
+
+func (s *Solver) solve(part, terms int) (solver.Answer, error) {
+	if s.report != nil && s.report.BigAmounts != nil {
+		return s.solveBig(part, terms)
+	}
+
+	solution, err := s.find(terms)
+	if err != nil {
+		return 0, err
+	}
+	s.describe(part, solution.Terms, solution.Indices)
+
+	product, err := solution.Product()
+	if err != nil {
+		return 0, err
+	}
+	return solver.Answer(product), nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return s.solve(1, s.part1Terms)
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return s.solve(2, s.part2Terms)
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
+
+func init() {
+	solver.Register(1, func() solver.Solver { return &Solver{details: make(map[int]solver.Details)} })
+}
@@ -0,0 +1,413 @@
+package day1
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+const (
+	DefaultRunSize = 1 << 20
+	recordSize     = 16
+	blockSize      = 4096
+)
+
+type ExternalOptions struct {
+	Report ReportOptions
+	// RunSize is the number of amounts sorted in memory at a time, which
+	// bounds the memory used to 16 bytes per amount.
+	RunSize int
+	TempDir string
+}
+
+type record struct {
+	value int64
+	index int64
+}
+
+func encodeRecords(records []record) []byte {
+	bytes := make([]byte, len(records)*recordSize)
+	for ix, r := range records {
+		binary.LittleEndian.PutUint64(bytes[ix*recordSize:], uint64(r.value))
+		binary.LittleEndian.PutUint64(bytes[ix*recordSize+8:], uint64(r.index))
+	}
+	return bytes
+}
+
+func decodeRecord(bytes []byte) record {
+	return record{
+		value: int64(binary.LittleEndian.Uint64(bytes)),
+		index: int64(binary.LittleEndian.Uint64(bytes[8:])),
+	}
+}
+
+// SortedReport is an expense report sorted by amount in a temporary file,
+// for reports too large to hold in memory.
+type SortedReport struct {
+	file  *os.File
+	count int64
+}
+
+func (report *SortedReport) Len() int64 {
+	return report.count
+}
+
+func (report *SortedReport) Close() error {
+	err := report.file.Close()
+	os.Remove(report.file.Name())
+	return err
+}
+
+// tempFile creates a temporary file that is removed right away where the
+// platform allows it, so that it does not outlive the process.
+func tempFile(dir, pattern string) (*os.File, error) {
+	file, err := ioutil.TempFile(dir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	os.Remove(file.Name())
+	return file, nil
+}
+
+func writeRun(dir string, records []record) (*os.File, error) {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].value < records[j].value
+	})
+
+	file, err := tempFile(dir, "expenses-run-*")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Write(encodeRecords(records)); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+type runReader struct {
+	reader *bufio.Reader
+	head   record
+}
+
+func (run *runReader) next() (bool, error) {
+	var bytes [recordSize]byte
+	if _, err := io.ReadFull(run.reader, bytes[:]); err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	run.head = decodeRecord(bytes[:])
+	return true, nil
+}
+
+type runHeap []*runReader
+
+func (h runHeap) Len() int            { return len(h) }
+func (h runHeap) Less(i, j int) bool  { return h[i].head.value < h[j].head.value }
+func (h runHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *runHeap) Push(x interface{}) { *h = append(*h, x.(*runReader)) }
+
+func (h *runHeap) Pop() interface{} {
+	old := *h
+	run := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return run
+}
+
+// mergeRuns merges the sorted runs into a single sorted file.
+func mergeRuns(dir string, runs []*os.File) (*os.File, error) {
+	runReaders := make(runHeap, 0, len(runs))
+	for _, run := range runs {
+		if _, err := run.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		reader := &runReader{reader: bufio.NewReader(run)}
+		ok, err := reader.next()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			runReaders = append(runReaders, reader)
+		}
+	}
+	heap.Init(&runReaders)
+
+	file, err := tempFile(dir, "expenses-sorted-*")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := bufio.NewWriter(file)
+	for runReaders.Len() > 0 {
+		run := runReaders[0]
+		if _, err := writer.Write(encodeRecords([]record{run.head})); err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		ok, err := run.next()
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		if ok {
+			heap.Fix(&runReaders, 0)
+		} else {
+			heap.Pop(&runReaders)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// SortExternal reads an expense report from r and sorts it in runs of
+// options.RunSize amounts that are spilled to temporary files and merged.
+// Unlike ReadReport it fails on the first line without a valid amount.
+func SortExternal(r io.Reader, options ExternalOptions) (*SortedReport, error) {
+	if options.Report.Big {
+		return nil, errors.New("Arbitrary-precision amounts cannot be sorted externally")
+	}
+
+	runSize := options.RunSize
+	if runSize <= 0 {
+		runSize = DefaultRunSize
+	}
+
+	runs := make([]*os.File, 0)
+	defer func() {
+		for _, run := range runs {
+			run.Close()
+			os.Remove(run.Name())
+		}
+	}()
+
+	records := make([]record, 0, runSize)
+	spill := func() error {
+		run, err := writeRun(options.TempDir, records)
+		if err != nil {
+			return err
+		}
+
+		runs = append(runs, run)
+		records = records[:0]
+		return nil
+	}
+
+	header := options.Report.Header
+	count := int64(0)
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+
+		text, column, err := options.Report.field(scanner.Text())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		value, err := input.ParseInt(text, column, 64)
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		records = append(records, record{value: value, index: count})
+		count += 1
+
+		if len(records) == runSize {
+			if err := spill(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(records) > 0 || len(runs) == 0 {
+		if err := spill(); err != nil {
+			return nil, err
+		}
+	}
+
+	var file *os.File
+	if len(runs) == 1 {
+		file = runs[0]
+		runs = runs[:0]
+	} else {
+		merged, err := mergeRuns(options.TempDir, runs)
+		if err != nil {
+			return nil, err
+		}
+		file = merged
+	}
+
+	return &SortedReport{file: file, count: count}, nil
+}
+
+// cursor reads records of a sorted report one block at a time. It is meant
+// for scanning the report in either direction.
+type cursor struct {
+	report *SortedReport
+	block  []record
+	start  int64
+}
+
+func (report *SortedReport) cursor() *cursor {
+	return &cursor{report: report}
+}
+
+func (c *cursor) at(ix int64) (record, error) {
+	if ix < c.start || ix >= c.start+int64(len(c.block)) {
+		c.start = ix - ix%blockSize
+		count := c.report.count - c.start
+		if count > blockSize {
+			count = blockSize
+		}
+
+		bytes := make([]byte, count*recordSize)
+		if _, err := c.report.file.ReadAt(bytes, c.start*recordSize); err != nil {
+			c.block = nil
+			return record{}, err
+		}
+
+		c.block = make([]record, count)
+		for i := range c.block {
+			c.block[i] = decodeRecord(bytes[i*recordSize:])
+		}
+	}
+
+	return c.block[ix-c.start], nil
+}
+
+// kSum is the counterpart of the in-memory kSum for the records from lower
+// up to upper, stopping at the first combination found.
+func (report *SortedReport) kSum(lower, upper, goal int64, terms int, chosen []record) ([]record, error) {
+	if upper-lower < int64(terms) {
+		return nil, nil
+	}
+
+	if terms == 1 {
+		c := report.cursor()
+		for ix := lower; ix < upper; ix += 1 {
+			r, err := c.at(ix)
+			if err != nil || r.value > goal {
+				return nil, err
+			}
+			if r.value == goal {
+				return append(chosen, r), nil
+			}
+		}
+		return nil, nil
+	}
+
+	if terms == 2 {
+		front := report.cursor()
+		back := report.cursor()
+		upper -= 1
+		for lower < upper {
+			low, err := front.at(lower)
+			if err != nil {
+				return nil, err
+			}
+			high, err := back.at(upper)
+			if err != nil {
+				return nil, err
+			}
+
+			switch compareSum(low.value, high.value, goal) {
+			case -1:
+				lower += 1
+			case 1:
+				upper -= 1
+			default:
+				return append(chosen, low, high), nil
+			}
+		}
+		return nil, nil
+	}
+
+	c := report.cursor()
+	previous := int64(0)
+	for ix := lower; ix <= upper-int64(terms); ix += 1 {
+		r, err := c.at(ix)
+		if err != nil {
+			return nil, err
+		}
+		if exceedsShare(r.value, goal, terms) {
+			break
+		}
+		if ix > lower && r.value == previous {
+			continue
+		}
+		previous = r.value
+
+		rest, ok := remainder(goal, r.value)
+		if !ok {
+			continue
+		}
+
+		found, err := report.kSum(ix+1, upper, rest, terms-1, append(chosen, r))
+		if found != nil || err != nil {
+			return found, err
+		}
+	}
+	return nil, nil
+}
+
+// FindSum returns a combination of terms amounts summing to goal. Pairs take a
+// single pass over the report, larger combinations take a pass for every
+// distinct amount tried as the first term.
+func (report *SortedReport) FindSum(goal, terms int) (Solution, error) {
+	if err := checkTerms(terms); err != nil {
+		return Solution{}, err
+	}
+
+	found, err := report.kSum(0, report.count, int64(goal), terms, nil)
+	if err != nil {
+		return Solution{}, err
+	}
+	if found == nil {
+		return Solution{}, &NoSolutionError{Goal: goal, Terms: terms}
+	}
+
+	solution := Solution{
+		Terms:   make([]int, len(found)),
+		Indices: make([]int, len(found)),
+	}
+	for ix, r := range found {
+		solution.Terms[ix] = int(r.value)
+		solution.Indices[ix] = int(r.index)
+	}
+	return solution, nil
+}
+
+// FindSumExternal is FindSum for reports read from r that are too large to
+// hold in memory.
+func FindSumExternal(r io.Reader, goal, terms int, options ExternalOptions) (Solution, error) {
+	report, err := SortExternal(r, options)
+	if err != nil {
+		return Solution{}, err
+	}
+	defer report.Close()
+
+	return report.FindSum(goal, terms)
+}
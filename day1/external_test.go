@@ -0,0 +1,97 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func reportText(expenses []int) string {
+	var text strings.Builder
+	for _, expense := range expenses {
+		fmt.Fprintln(&text, expense)
+	}
+	return text.String()
+}
+
+func TestFindSumExternal(t *testing.T) {
+	inputs := [][]int{
+		{},
+		{2020},
+		example,
+		{1010, 1010, 1010, 5, 5, 2015},
+		{-5, 2025, -5, 0, 7, 2013, -2000, 4020},
+		{1 << 61, -(1 << 62), 1 << 61, math.MaxInt64, math.MinInt64},
+	}
+
+	random := rand.New(rand.NewSource(14))
+	for round := 0; round < 5; round += 1 {
+		expenses := make([]int, 5+random.Intn(20))
+		for ix := range expenses {
+			expenses[ix] = random.Intn(41) - 20
+		}
+		inputs = append(inputs, expenses)
+	}
+
+	for _, expenses := range inputs {
+		goals := []int{0, 2020, 2030, math.MinInt64, math.MaxInt64}
+		for ix := range expenses {
+			goals = append(goals, expenses[ix], expenses[ix]+expenses[len(expenses)-1-ix])
+		}
+
+		for runSize := 1; runSize <= len(expenses)+1; runSize += 1 {
+			report, err := SortExternal(strings.NewReader(reportText(expenses)), ExternalOptions{RunSize: runSize, TempDir: t.TempDir()})
+			if err != nil {
+				t.Fatalf("%v, run size %d: %s", expenses, runSize, err)
+			}
+			if report.Len() != int64(len(expenses)) {
+				t.Errorf("%v, run size %d: expected %d amounts, got %d", expenses, runSize, len(expenses), report.Len())
+			}
+
+			for _, goal := range goals {
+				for terms := 1; terms <= 4; terms += 1 {
+					expected, expectedErr := FindSum(expenses, goal, terms)
+					solution, err := report.FindSum(goal, terms)
+
+					var noSolution *NoSolutionError
+					if expectedErr != nil {
+						if !errors.As(err, &noSolution) {
+							t.Errorf("%v, run size %d, %d terms to %d: expected no solution, got %v (%v)",
+								expenses, runSize, terms, goal, solution.Terms, err)
+						}
+						continue
+					}
+
+					if err != nil {
+						t.Errorf("%v, run size %d, %d terms to %d: expected %v, got %s",
+							expenses, runSize, terms, goal, expected.Terms, err)
+						continue
+					}
+
+					checkSolution(t, expenses, solution)
+					if len(solution.Terms) != terms || solution.Sum() != goal {
+						t.Errorf("%v, run size %d, %d terms to %d: got %v", expenses, runSize, terms, goal, solution.Terms)
+					}
+				}
+			}
+
+			if err := report.Close(); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+func TestFindSumExternalInvalid(t *testing.T) {
+	if _, err := FindSumExternal(strings.NewReader("1\nx\n"), 2020, 2, ExternalOptions{RunSize: 1, TempDir: t.TempDir()}); err == nil {
+		t.Errorf("expected an error for an invalid amount")
+	}
+
+	solution, err := FindSumExternal(strings.NewReader(reportText(example)), 2020, 3, ExternalOptions{RunSize: 2, TempDir: t.TempDir()})
+	if err != nil || solution.Sum() != 2020 || len(solution.Terms) != 3 {
+		t.Errorf("expected three terms summing to 2020, got %v (%v)", solution.Terms, err)
+	}
+}
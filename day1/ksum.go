@@ -0,0 +1,271 @@
+package day1
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// Solution is a combination of expenses summing to the goal. Terms are in
+// ascending order and Indices holds the position of each term in the report.
+type Solution struct {
+	Terms   []int
+	Indices []int
+}
+
+// Product returns the product of the terms, or an error when it does not fit
+// in an int.
+func (solution Solution) Product() (int, error) {
+	product := big.NewInt(1)
+	for _, term := range solution.Terms {
+		product.Mul(product, big.NewInt(int64(term)))
+	}
+
+	if !product.IsInt64() || int64(int(product.Int64())) != product.Int64() {
+		return 0, fmt.Errorf("Product %s of %v does not fit in an int", product, solution.Terms)
+	}
+	return int(product.Int64()), nil
+}
+
+// NoSolutionError reports that no combination of Terms expenses sums to Goal.
+// Terms is 0 when subsets of any size were considered.
+type NoSolutionError struct {
+	Goal  int
+	Terms int
+}
+
+func (e *NoSolutionError) Error() string {
+	if e.Terms == 0 {
+		return fmt.Sprintf("No subset of the expenses sums to %d", e.Goal)
+	}
+	return fmt.Sprintf("No %d expenses sum to %d", e.Terms, e.Goal)
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	quotient := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		quotient -= 1
+	}
+	return quotient
+}
+
+// exceedsShare reports whether value*terms > goal without computing the
+// product, which may not fit in an int64.
+func exceedsShare(value, goal int64, terms int) bool {
+	return value > floorDiv(goal, int64(terms))
+}
+
+// compareSum compares a+b with goal without computing the sum, which may not
+// fit in an int64.
+func compareSum(a, b, goal int64) int {
+	switch {
+	case b > 0 && goal < math.MinInt64+b:
+		return 1
+	case b < 0 && goal > math.MaxInt64+b:
+		return -1
+	}
+
+	rest := goal - b
+	switch {
+	case a < rest:
+		return -1
+	case a > rest:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// remainder returns goal-value, and false when it does not fit in an int64.
+func remainder(goal, value int64) (int64, bool) {
+	rest := goal - value
+	return rest, value == 0 || (value > 0 && rest < goal) || (value < 0 && rest > goal)
+}
+
+type entry struct {
+	value int
+	index int
+}
+
+func sortedEntries(expenses []int) []entry {
+	entries := make([]entry, len(expenses))
+	for ix, expense := range expenses {
+		entries[ix] = entry{value: expense, index: ix}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].value < entries[j].value
+	})
+	return entries
+}
+
+func newSolution(chosen []entry) Solution {
+	solution := Solution{
+		Terms:   make([]int, len(chosen)),
+		Indices: make([]int, len(chosen)),
+	}
+	for ix, e := range chosen {
+		solution.Terms[ix] = e.value
+		solution.Indices[ix] = e.index
+	}
+	return solution
+}
+
+// kSum visits every distinct combination of terms entries summing to goal
+// until visit returns false. Entries must be sorted by value; combinations
+// are distinct by their values, using the first occurrence of each value.
+// Combinations are only found when goal minus any of their leading terms
+// fits in an int.
+func kSum(entries []entry, goal, terms int, chosen []entry, visit func([]entry) bool) bool {
+	if len(entries) < terms {
+		return true
+	}
+
+	switch terms {
+	case 1:
+		ix := sort.Search(len(entries), func(i int) bool {
+			return entries[i].value >= goal
+		})
+		if ix < len(entries) && entries[ix].value == goal {
+			return visit(append(chosen, entries[ix]))
+		}
+		return true
+
+	case 2:
+		lower := 0
+		upper := len(entries) - 1
+		for lower < upper {
+			switch compareSum(int64(entries[lower].value), int64(entries[upper].value), int64(goal)) {
+			case -1:
+				lower += 1
+			case 1:
+				upper -= 1
+			default:
+				last := upper
+				for last > lower+1 && entries[last-1].value == entries[upper].value {
+					last -= 1
+				}
+
+				if !visit(append(chosen, entries[lower], entries[last])) {
+					return false
+				}
+
+				for lower < upper && entries[lower+1].value == entries[lower].value {
+					lower += 1
+				}
+				lower += 1
+				upper = last - 1
+			}
+		}
+		return true
+
+	default:
+		for ix := 0; ix <= len(entries)-terms; ix += 1 {
+			if exceedsShare(int64(entries[ix].value), int64(goal), terms) {
+				break
+			}
+			if ix > 0 && entries[ix].value == entries[ix-1].value {
+				continue
+			}
+
+			rest, ok := remainder(int64(goal), int64(entries[ix].value))
+			if !ok {
+				continue
+			}
+
+			if !kSum(entries[ix+1:], int(rest), terms-1, append(chosen, entries[ix]), visit) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// twoSum finds a pair summing to goal in a single pass over the report,
+// remembering where each value was first seen.
+func twoSum(expenses []int, goal int) (Solution, bool) {
+	seen := make(map[int]int)
+	for ix, expense := range expenses {
+		_, fits := remainder(int64(goal), int64(expense))
+		if other, ok := seen[goal-expense]; ok && fits {
+			if expense < goal-expense {
+				return Solution{Terms: []int{expense, goal - expense}, Indices: []int{ix, other}}, true
+			}
+			return Solution{Terms: []int{goal - expense, expense}, Indices: []int{other, ix}}, true
+		}
+
+		if _, ok := seen[expense]; !ok {
+			seen[expense] = ix
+		}
+	}
+	return Solution{}, false
+}
+
+func checkTerms(terms int) error {
+	if terms < 1 {
+		return fmt.Errorf("Number of terms should be positive: %d", terms)
+	}
+	return nil
+}
+
+// FindSum returns a combination of terms expenses summing to goal, or a
+// NoSolutionError when there is none.
+func FindSum(expenses []int, goal, terms int) (Solution, error) {
+	if err := checkTerms(terms); err != nil {
+		return Solution{}, err
+	}
+
+	if terms == 2 {
+		if solution, ok := twoSum(expenses, goal); ok {
+			return solution, nil
+		}
+		return Solution{}, &NoSolutionError{Goal: goal, Terms: terms}
+	}
+
+	var solution Solution
+	found := false
+	kSum(sortedEntries(expenses), goal, terms, nil, func(chosen []entry) bool {
+		solution = newSolution(chosen)
+		found = true
+		return false
+	})
+
+	if !found {
+		return Solution{}, &NoSolutionError{Goal: goal, Terms: terms}
+	}
+	return solution, nil
+}
+
+// FindAllSums returns every combination of terms expenses summing to goal
+// that differs in the values of its terms.
+func FindAllSums(expenses []int, goal, terms int) ([]Solution, error) {
+	if err := checkTerms(terms); err != nil {
+		return nil, err
+	}
+
+	solutions := make([]Solution, 0)
+	kSum(sortedEntries(expenses), goal, terms, nil, func(chosen []entry) bool {
+		solutions = append(solutions, newSolution(chosen))
+		return true
+	})
+
+	if len(solutions) == 0 {
+		return nil, &NoSolutionError{Goal: goal, Terms: terms}
+	}
+	return solutions, nil
+}
+
+func CountSums(expenses []int, goal, terms int) (int, error) {
+	if err := checkTerms(terms); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	kSum(sortedEntries(expenses), goal, terms, nil, func(chosen []entry) bool {
+		count += 1
+		return true
+	})
+	return count, nil
+}
@@ -0,0 +1,146 @@
+package day1
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var example = []int{1721, 979, 366, 299, 675, 1456}
+
+// checkSolution verifies that the indices of solution point at its terms and
+// that no expense is used twice.
+func checkSolution(t *testing.T, expenses []int, solution Solution) {
+	t.Helper()
+
+	if len(solution.Terms) != len(solution.Indices) {
+		t.Fatalf("%d terms but %d indices", len(solution.Terms), len(solution.Indices))
+	}
+
+	used := make(map[int]bool)
+	for ix, index := range solution.Indices {
+		if used[index] {
+			t.Errorf("index %d used twice in %v", index, solution.Indices)
+		}
+		used[index] = true
+
+		if expenses[index] != solution.Terms[ix] {
+			t.Errorf("index %d holds %d, not %d", index, expenses[index], solution.Terms[ix])
+		}
+	}
+}
+
+func TestFindSum(t *testing.T) {
+	cases := []struct {
+		expenses []int
+		goal     int
+		terms    int
+		expected []int
+	}{
+		{example, 2020, 1, nil},
+		{[]int{3, 2020, 5}, 2020, 1, []int{2020}},
+		{example, 2020, 2, []int{299, 1721}},
+		{example, 2020, 3, []int{366, 675, 979}},
+		{[]int{100, 4, 3, 2, 1}, 10, 4, []int{1, 2, 3, 4}},
+		{[]int{1010, 5, 1010}, 2020, 2, []int{1010, 1010}},
+		{[]int{1010, 5}, 2020, 2, nil},
+		{[]int{1010, 5, 1010}, 3030, 3, nil},
+		{[]int{673, 673, 674, 5}, 2020, 3, []int{673, 673, 674}},
+		{[]int{30, -5, 2025, 7}, 2020, 2, []int{-5, 2025}},
+		{[]int{-10, 20, -30, 2040}, 2020, 3, nil},
+		{[]int{-10, 20, -30, 2040}, 2000, 3, []int{-30, -10, 2040}},
+		{[]int{1 << 61, -(1 << 62), 1 << 61}, 0, 3, []int{-(1 << 62), 1 << 61, 1 << 61}},
+		{[]int{math.MaxInt64, 1}, math.MinInt64, 2, nil},
+		{[]int{0, math.MaxInt64, 1}, math.MinInt64, 3, nil},
+		{nil, 2020, 2, nil},
+	}
+
+	for _, c := range cases {
+		solution, err := FindSum(c.expenses, c.goal, c.terms)
+		if len(c.expected) == 0 {
+			var noSolution *NoSolutionError
+			if !errors.As(err, &noSolution) {
+				t.Errorf("%v, %d terms to %d: expected no solution, got %v (%v)", c.expenses, c.terms, c.goal, solution.Terms, err)
+			} else if noSolution.Goal != c.goal || noSolution.Terms != c.terms {
+				t.Errorf("%v, %d terms to %d: unexpected error %+v", c.expenses, c.terms, c.goal, noSolution)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%v, %d terms to %d: %s", c.expenses, c.terms, c.goal, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(solution.Terms, c.expected) {
+			t.Errorf("%v, %d terms to %d: expected %v, got %v", c.expenses, c.terms, c.goal, c.expected, solution.Terms)
+		}
+		checkSolution(t, c.expenses, solution)
+	}
+}
+
+func TestFindSumInvalidTerms(t *testing.T) {
+	var noSolution *NoSolutionError
+	if _, err := FindSum(example, 2020, 0); err == nil || errors.As(err, &noSolution) {
+		t.Errorf("expected an invalid terms error, got %v", err)
+	}
+}
+
+func TestFindAllSums(t *testing.T) {
+	cases := []struct {
+		expenses []int
+		goal     int
+		terms    int
+		expected [][]int
+	}{
+		{[]int{3, 1, 4, 1, 3, 2}, 5, 2, [][]int{{1, 4}, {2, 3}}},
+		{[]int{3, 1, 4, 1, 3, 2}, 7, 3, [][]int{{1, 2, 4}, {1, 3, 3}}},
+		{[]int{3, 1, 4, 1, 3, 2}, 5, 3, [][]int{{1, 1, 3}}},
+		{[]int{-2, 2, -1, 1, 0}, 0, 2, [][]int{{-2, 2}, {-1, 1}}},
+		{[]int{-2, 2, -1, 1, 0}, 0, 4, [][]int{{-2, -1, 1, 2}}},
+		{[]int{2, 2, 2, 2}, 4, 2, [][]int{{2, 2}}},
+	}
+
+	for _, c := range cases {
+		solutions, err := FindAllSums(c.expenses, c.goal, c.terms)
+		if err != nil {
+			t.Errorf("%v, %d terms to %d: %s", c.expenses, c.terms, c.goal, err)
+			continue
+		}
+
+		found := make([][]int, len(solutions))
+		for ix, solution := range solutions {
+			found[ix] = solution.Terms
+			checkSolution(t, c.expenses, solution)
+		}
+		if !reflect.DeepEqual(found, c.expected) {
+			t.Errorf("%v, %d terms to %d: expected %v, got %v", c.expenses, c.terms, c.goal, c.expected, found)
+		}
+
+		count, err := CountSums(c.expenses, c.goal, c.terms)
+		if err != nil || count != len(c.expected) {
+			t.Errorf("%v, %d terms to %d: expected %d, counted %d (%v)", c.expenses, c.terms, c.goal, len(c.expected), count, err)
+		}
+	}
+
+	var noSolution *NoSolutionError
+	if _, err := FindAllSums(example, 1, 2); !errors.As(err, &noSolution) {
+		t.Errorf("expected no solution, got %v", err)
+	}
+	if count, err := CountSums(example, 1, 2); count != 0 || err != nil {
+		t.Errorf("expected no sums, counted %d (%v)", count, err)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	if product, err := (Solution{Terms: []int{299, 1721}}).Product(); product != 514579 || err != nil {
+		t.Errorf("expected 514579, got %d (%v)", product, err)
+	}
+	if product, err := (Solution{Terms: []int{-(1 << 31), 1 << 32}}).Product(); product != -(1<<63) || err != nil {
+		t.Errorf("expected %d, got %d (%v)", -(1 << 63), product, err)
+	}
+	if product, err := (Solution{Terms: []int{1 << 40, 1 << 40}}).Product(); err == nil {
+		t.Errorf("expected an overflow, got %d", product)
+	}
+}
@@ -0,0 +1,168 @@
+package day1
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+type ReportOptions struct {
+	// Column is the 1-based column holding the amount in comma separated
+	// records, or 0 when every line holds a single amount.
+	Column int
+	Comma  rune
+	Header bool
+	// Big parses amounts as arbitrary-precision integers into BigAmounts
+	// instead of into Amounts.
+	Big bool
+}
+
+// Report is an expense report along with the records that were rejected.
+// Lines holds the line number of every accepted amount.
+type Report struct {
+	Amounts    []int64
+	BigAmounts []*big.Int
+	Lines      []int
+	Records    int
+	Rejected   []*input.ParseError
+}
+
+func summary(accepted, records, rejected int) string {
+	return fmt.Sprintf("%d of %d records accepted, %d rejected", accepted, records, rejected)
+}
+
+// RejectedError lists the records of a report that were rejected, and its
+// message is the summary of the report.
+type RejectedError struct {
+	Records  int
+	Accepted int
+	Rejected []*input.ParseError
+}
+
+func (e *RejectedError) Error() string {
+	return summary(e.Accepted, e.Records, len(e.Rejected))
+}
+
+func (e *RejectedError) Errors() []error {
+	errors := make([]error, len(e.Rejected))
+	for ix, rejected := range e.Rejected {
+		errors[ix] = rejected
+	}
+	return errors
+}
+
+func (report *Report) Accepted() int {
+	return len(report.Lines)
+}
+
+func (report *Report) Summary() string {
+	return summary(report.Accepted(), report.Records, len(report.Rejected))
+}
+
+// Err returns a RejectedError listing every rejected record, or nil when all
+// records were accepted.
+func (report *Report) Err() error {
+	if len(report.Rejected) == 0 {
+		return nil
+	}
+	return &RejectedError{Records: report.Records, Accepted: report.Accepted(), Rejected: report.Rejected}
+}
+
+func (report *Report) Expenses() []int {
+	expenses := make([]int, len(report.Amounts))
+	for ix, amount := range report.Amounts {
+		expenses[ix] = int(amount)
+	}
+	return expenses
+}
+
+// field returns the text of the amount in line and the column it starts at.
+func (options ReportOptions) field(line string) (string, int, error) {
+	if options.Column == 0 {
+		text := strings.TrimSpace(line)
+		return text, strings.Index(line, text) + 1, nil
+	}
+
+	reader := csv.NewReader(strings.NewReader(line))
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	fields, err := reader.Read()
+	if err != nil {
+		return "", 0, input.Errorf(0, "Invalid record: %s", err)
+	}
+
+	if options.Column > len(fields) {
+		return "", 0, input.Errorf(len(line)+1, "Expected at least %d columns, got %d", options.Column, len(fields))
+	}
+
+	text := strings.TrimSpace(fields[options.Column-1])
+	column := 0
+	if offset := strings.Index(line, text); offset >= 0 && text != "" {
+		column = offset + 1
+	}
+	return text, column, nil
+}
+
+func (report *Report) add(text string, column int, arbitrary bool) error {
+	if arbitrary {
+		amount, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return input.Errorf(column, "Invalid number %q", text)
+		}
+		report.BigAmounts = append(report.BigAmounts, amount)
+		return nil
+	}
+
+	amount, err := input.ParseInt(text, column, 64)
+	if err != nil {
+		return err
+	}
+	report.Amounts = append(report.Amounts, amount)
+	return nil
+}
+
+// ReadReport reads an expense report, collecting the records that do not
+// hold a valid amount in Rejected rather than failing on them. Only errors
+// reading r are returned.
+func ReadReport(r io.Reader, options ReportOptions) (*Report, error) {
+	report := &Report{
+		Lines:    make([]int, 0),
+		Rejected: make([]*input.ParseError, 0),
+	}
+	if options.Big {
+		report.BigAmounts = make([]*big.Int, 0)
+	} else {
+		report.Amounts = make([]int64, 0)
+	}
+
+	header := options.Header
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+
+		report.Records += 1
+		text, column, err := options.field(scanner.Text())
+		if err == nil {
+			err = report.add(text, column, options.Big)
+		}
+
+		if err != nil {
+			report.Rejected = append(report.Rejected, scanner.Wrap(err).(*input.ParseError))
+			continue
+		}
+		report.Lines = append(report.Lines, scanner.Line())
+	}
+
+	return report, scanner.Err()
+}
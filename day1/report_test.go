@@ -0,0 +1,93 @@
+package day1
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadReport(t *testing.T) {
+	cases := []struct {
+		text     string
+		options  ReportOptions
+		amounts  []int64
+		lines    []int
+		records  int
+		rejected [][2]int
+	}{
+		{"1721\n979\n", ReportOptions{}, []int64{1721, 979}, []int{1, 2}, 2, [][2]int{}},
+		{" 1721 \n3000000000\n", ReportOptions{}, []int64{1721, 3000000000}, []int{1, 2}, 2, [][2]int{}},
+		{"1721\n\nabc\n\n979\n 9x\n", ReportOptions{}, []int64{1721, 979}, []int{1, 5}, 4, [][2]int{{3, 1}, {6, 2}}},
+		{"amount\n1721\n979\n", ReportOptions{Header: true}, []int64{1721, 979}, []int{2, 3}, 2, [][2]int{}},
+		{"a,1721\nb,x\n", ReportOptions{Column: 2}, []int64{1721}, []int{1}, 2, [][2]int{{2, 3}}},
+		{"\"a,b\",\"1721\"\n\"c\", 979 \n", ReportOptions{Column: 2}, []int64{1721, 979}, []int{1, 2}, 2, [][2]int{}},
+		{"\"a,b\",\"17x1\"\n", ReportOptions{Column: 2}, []int64{}, []int{}, 1, [][2]int{{1, 8}}},
+		{"a;1721\nb\nc;979\n", ReportOptions{Column: 2, Comma: ';'}, []int64{1721, 979}, []int{1, 3}, 3, [][2]int{{2, 2}}},
+		{"name,amount\na,1\nb\n", ReportOptions{Column: 2, Header: true}, []int64{1}, []int{2}, 2, [][2]int{{3, 2}}},
+	}
+
+	for _, c := range cases {
+		report, err := ReadReport(strings.NewReader(c.text), c.options)
+		if err != nil {
+			t.Errorf("%q: %s", c.text, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(report.Amounts, c.amounts) || !reflect.DeepEqual(report.Lines, c.lines) || report.Records != c.records {
+			t.Errorf("%q: expected %v on lines %v of %d records, got %v on lines %v of %d",
+				c.text, c.amounts, c.lines, c.records, report.Amounts, report.Lines, report.Records)
+		}
+
+		rejected := make([][2]int, len(report.Rejected))
+		for ix, parseError := range report.Rejected {
+			rejected[ix] = [2]int{parseError.Line, parseError.Column}
+		}
+		if !reflect.DeepEqual(rejected, c.rejected) {
+			t.Errorf("%q: expected rejected lines and columns %v, got %v", c.text, c.rejected, rejected)
+		}
+	}
+}
+
+func TestReportErr(t *testing.T) {
+	report, err := ReadReport(strings.NewReader("1721\nx\n\n979\n12345678901234567890\n"), ReportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary := report.Summary(); summary != "2 of 4 records accepted, 2 rejected" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+
+	var rejectedError *RejectedError
+	if !errors.As(report.Err(), &rejectedError) {
+		t.Fatalf("expected a RejectedError, got %v", report.Err())
+	}
+	if rejectedError.Error() != report.Summary() {
+		t.Errorf("expected the summary as message, got %q", rejectedError)
+	}
+
+	rejected := rejectedError.Errors()
+	if len(rejected) != 2 || !strings.HasPrefix(rejected[0].Error(), "2:1:") || !strings.HasPrefix(rejected[1].Error(), "5:1:") {
+		t.Errorf("expected lines 2 and 5 to be rejected, got %v", rejected)
+	}
+
+	report, err = ReadReport(strings.NewReader("1721\n979\n"), ReportOptions{})
+	if err != nil || report.Err() != nil {
+		t.Errorf("expected no rejected records, got %v (%v)", report.Err(), err)
+	}
+}
+
+func TestReadReportBig(t *testing.T) {
+	report, err := ReadReport(strings.NewReader("12345678901234567890\n-1\nx\n"), ReportOptions{Big: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.BigAmounts) != 2 || report.BigAmounts[0].String() != "12345678901234567890" || report.Amounts != nil {
+		t.Errorf("unexpected amounts %v, %v", report.BigAmounts, report.Amounts)
+	}
+	if len(report.Rejected) != 1 || report.Rejected[0].Line != 3 {
+		t.Errorf("expected line 3 to be rejected, got %v", report.Rejected)
+	}
+}
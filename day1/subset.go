@@ -0,0 +1,201 @@
+package day1
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var errNegativeExpense = errors.New("Subset sums need non-negative expenses")
+
+func (solution Solution) Sum() int {
+	sum := 0
+	for _, term := range solution.Terms {
+		sum += term
+	}
+	return sum
+}
+
+func checkSubset(expenses []int, goal int) error {
+	if goal < 0 {
+		return errors.New("Subset sums need a non-negative goal")
+	}
+
+	for _, expense := range expenses {
+		if expense < 0 {
+			return errNegativeExpense
+		}
+	}
+	return nil
+}
+
+// subsetTable returns, for every sum up to goal, the index of the expense
+// with which that sum first became reachable or -1 when it is not reachable.
+// The empty sum is always reachable.
+// The remainder of a sum is always reached by expenses with a lower index, so
+// following the table never uses an expense twice.
+func subsetTable(expenses []int, goal int) []int {
+	via := make([]int, goal+1)
+	for sum := 1; sum <= goal; sum += 1 {
+		via[sum] = -1
+	}
+
+	for ix, expense := range expenses {
+		if expense == 0 || expense > goal {
+			continue
+		}
+
+		for sum := goal; sum >= expense; sum -= 1 {
+			if via[sum] < 0 && via[sum-expense] >= 0 {
+				via[sum] = ix
+			}
+		}
+	}
+
+	return via
+}
+
+// subsetSolution returns the expenses at indices as a solution with its terms
+// in ascending order.
+func subsetSolution(expenses []int, indices []int) Solution {
+	chosen := make([]entry, len(indices))
+	for ix, index := range indices {
+		chosen[ix] = entry{value: expenses[index], index: index}
+	}
+
+	sort.Slice(chosen, func(i, j int) bool {
+		if chosen[i].value != chosen[j].value {
+			return chosen[i].value < chosen[j].value
+		}
+		return chosen[i].index < chosen[j].index
+	})
+	return newSolution(chosen)
+}
+
+func reconstruct(expenses []int, via []int, sum int) Solution {
+	indices := make([]int, 0)
+	for sum > 0 {
+		indices = append(indices, via[sum])
+		sum -= expenses[via[sum]]
+	}
+	return subsetSolution(expenses, indices)
+}
+
+// FindSubset returns a subset of any size of expenses summing to goal. It
+// needs memory proportional to goal, see FindSubsetBounded for large goals.
+func FindSubset(expenses []int, goal int) (Solution, error) {
+	if err := checkSubset(expenses, goal); err != nil {
+		return Solution{}, err
+	}
+
+	via := subsetTable(expenses, goal)
+	if via[goal] < 0 {
+		return Solution{}, &NoSolutionError{Goal: goal}
+	}
+	return reconstruct(expenses, via, goal), nil
+}
+
+// ClosestSubset returns the subset of expenses with the largest sum that does
+// not exceed goal.
+func ClosestSubset(expenses []int, goal int) (Solution, error) {
+	if err := checkSubset(expenses, goal); err != nil {
+		return Solution{}, err
+	}
+
+	via := subsetTable(expenses, goal)
+	sum := goal
+	for via[sum] < 0 {
+		sum -= 1
+	}
+	return reconstruct(expenses, via, sum), nil
+}
+
+// CountSubsets returns the number of subsets of expenses summing to goal,
+// where equal expenses at different positions make different subsets.
+func CountSubsets(expenses []int, goal int) (*big.Int, error) {
+	if err := checkSubset(expenses, goal); err != nil {
+		return nil, err
+	}
+
+	counts := make([]*big.Int, goal+1)
+	for sum := range counts {
+		counts[sum] = new(big.Int)
+	}
+	counts[0].SetInt64(1)
+
+	for _, expense := range expenses {
+		if expense > goal {
+			continue
+		}
+
+		for sum := goal; sum >= expense; sum -= 1 {
+			if counts[sum-expense].Sign() != 0 {
+				counts[sum].Add(counts[sum], counts[sum-expense])
+			}
+		}
+	}
+
+	return counts[goal], nil
+}
+
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (set bitset) has(ix int) bool {
+	return set[ix/64]&(1<<uint(ix%64)) != 0
+}
+
+// shiftOr adds every element of set shifted by offset to set, dropping the
+// elements beyond its size.
+func (set bitset) shiftOr(offset int) {
+	words := offset / 64
+	shift := uint(offset % 64)
+	for ix := len(set) - 1; ix >= words; ix -= 1 {
+		word := set[ix-words] << shift
+		if shift > 0 && ix-words > 0 {
+			word |= set[ix-words-1] >> (64 - shift)
+		}
+		set[ix] |= word
+	}
+}
+
+// reachable returns the sums up to goal of the subsets of expenses.
+func reachable(expenses []int, goal int) bitset {
+	set := newBitset(goal + 1)
+	set[0] = 1
+	for _, expense := range expenses {
+		if expense > 0 && expense <= goal {
+			set.shiftOr(expense)
+		}
+	}
+	return set
+}
+
+// FindSubsetBounded is FindSubset using a single bit per sum, which makes it
+// usable for goals far too large for the table FindSubset needs. Instead of
+// remembering how every sum was reached it recomputes the reachable sums for
+// each expense, which makes it quadratic in the number of expenses.
+func FindSubsetBounded(expenses []int, goal int) (Solution, error) {
+	if err := checkSubset(expenses, goal); err != nil {
+		return Solution{}, err
+	}
+
+	if !reachable(expenses, goal).has(goal) {
+		return Solution{}, &NoSolutionError{Goal: goal}
+	}
+
+	indices := make([]int, 0)
+	sum := goal
+	for ix := len(expenses) - 1; ix >= 0 && sum > 0; ix -= 1 {
+		if reachable(expenses[:ix], sum).has(sum) {
+			continue
+		}
+
+		indices = append(indices, ix)
+		sum -= expenses[ix]
+	}
+	return subsetSolution(expenses, indices), nil
+}
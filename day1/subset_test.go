@@ -0,0 +1,87 @@
+package day1
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// bruteSubsets counts the subsets of expenses for every sum up to goal.
+func bruteSubsets(expenses []int, goal int) []int64 {
+	counts := make([]int64, goal+1)
+	for mask := 0; mask < 1<<uint(len(expenses)); mask += 1 {
+		sum := 0
+		for ix, expense := range expenses {
+			if mask&(1<<uint(ix)) != 0 {
+				sum += expense
+			}
+		}
+		if sum <= goal {
+			counts[sum] += 1
+		}
+	}
+	return counts
+}
+
+func checkSubsetSolution(t *testing.T, expenses []int, solution Solution, sum int) {
+	t.Helper()
+
+	checkSolution(t, expenses, solution)
+	if solution.Sum() != sum {
+		t.Errorf("%v: expected a sum of %d, got %v", expenses, sum, solution.Terms)
+	}
+	if !sort.IntsAreSorted(solution.Terms) {
+		t.Errorf("%v: terms %v are not in ascending order", expenses, solution.Terms)
+	}
+}
+
+func TestSubsetsBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(2020))
+	for round := 0; round < 300; round += 1 {
+		expenses := make([]int, random.Intn(10))
+		for ix := range expenses {
+			expenses[ix] = random.Intn(25)
+		}
+		goal := random.Intn(80)
+		counts := bruteSubsets(expenses, goal)
+
+		solution, err := FindSubset(expenses, goal)
+		bounded, boundedErr := FindSubsetBounded(expenses, goal)
+		if counts[goal] == 0 {
+			var noSolution *NoSolutionError
+			if !errors.As(err, &noSolution) || !errors.As(boundedErr, &noSolution) {
+				t.Errorf("%v to %d: expected no subset, got %v (%v) and %v (%v)",
+					expenses, goal, solution.Terms, err, bounded.Terms, boundedErr)
+			}
+		} else if err != nil || boundedErr != nil {
+			t.Errorf("%v to %d: %v, %v", expenses, goal, err, boundedErr)
+		} else {
+			checkSubsetSolution(t, expenses, solution, goal)
+			checkSubsetSolution(t, expenses, bounded, goal)
+		}
+
+		closest := goal
+		for counts[closest] == 0 {
+			closest -= 1
+		}
+		if solution, err := ClosestSubset(expenses, goal); err != nil {
+			t.Errorf("%v to %d: %s", expenses, goal, err)
+		} else {
+			checkSubsetSolution(t, expenses, solution, closest)
+		}
+
+		if count, err := CountSubsets(expenses, goal); err != nil || !count.IsInt64() || count.Int64() != counts[goal] {
+			t.Errorf("%v to %d: expected %d subsets, counted %v (%v)", expenses, goal, counts[goal], count, err)
+		}
+	}
+}
+
+func TestSubsetsNegative(t *testing.T) {
+	if _, err := FindSubset([]int{1, -1}, 2); err == nil {
+		t.Errorf("expected an error for a negative expense")
+	}
+	if _, err := FindSubsetBounded([]int{1, 2}, -1); err == nil {
+		t.Errorf("expected an error for a negative goal")
+	}
+}
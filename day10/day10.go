@@ -0,0 +1,116 @@
+package day10
+
+import (
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func ReadAdapters(r io.Reader) ([]int, error) {
+	return input.Ints(r)
+}
+
+func FindAdapters(adapters []int, source, target int) []int {
+	if target-source <= 3 {
+		return []int{source, target}
+	}
+
+	path := make([]int, 0)
+	path = append(path, source)
+	for _, adapter := range adapters {
+		if adapter > source && adapter-source <= 3 {
+			remaining := FindAdapters(adapters, adapter, target)
+			if len(remaining) != 0 {
+				path = append(path, remaining...)
+				return path
+			}
+		}
+	}
+
+	return []int{}
+}
+
+func CountDiff(path []int, diff int) int {
+	count := 0
+	for ix := 1; ix < len(path); ix += 1 {
+		if path[ix]-path[ix-1] == diff {
+			count += 1
+		}
+	}
+	return count
+}
+
+func CountAdaptersOptions(adapters []int, source, target int, optionsCache map[int]int64) int64 {
+	if options, ok := optionsCache[source]; ok {
+		return options
+	}
+
+	if target-source == 3 {
+		return 1
+	}
+
+	options := int64(0)
+	for _, adapter := range adapters {
+		if adapter > source && adapter-source <= 3 {
+			options += CountAdaptersOptions(adapters, adapter, target, optionsCache)
+		}
+	}
+
+	optionsCache[source] = options
+	return options
+}
+
+type Solver struct {
+	adapters []int
+	target   int
+	details  map[int]solver.Details
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	allAdapters, err := ReadAdapters(r)
+	if err != nil {
+		return err
+	}
+
+	if len(allAdapters) == 0 {
+		return errors.New("No adapters found")
+	}
+
+	sort.Ints(allAdapters)
+	s.target = allAdapters[len(allAdapters)-1] + 3
+	s.adapters = FindAdapters(allAdapters, 0, s.target)
+	if len(s.adapters) == 0 {
+		return errors.New("Adapters cannot be chained to the device")
+	}
+
+	return nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	oneDiffCount := CountDiff(s.adapters, 1)
+	threeDiffCount := CountDiff(s.adapters, 3)
+
+	s.details[1] = solver.Details{
+		"one_diffs":   oneDiffCount,
+		"three_diffs": threeDiffCount,
+	}
+
+	return solver.Answer(oneDiffCount * threeDiffCount), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Answer(CountAdaptersOptions(s.adapters, 0, s.target, make(map[int]int64))), nil
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
+
+func init() {
+	solver.Register(10, func() solver.Solver {
+		return &Solver{details: make(map[int]solver.Details)}
+	})
+}
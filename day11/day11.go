@@ -0,0 +1,256 @@
+package day11
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type State int
+
+const (
+	FLOOR State = iota
+	EMPTY
+	OCCUPIED
+)
+
+type Board struct {
+	state [][]State
+}
+
+func (board *Board) CountOccupiedNeighboursv2(i, j int) int {
+	directions := [][]int{
+		{0, 1},
+		{1, 1},
+		{1, 0},
+		{1, -1},
+		{0, -1},
+		{-1, -1},
+		{-1, 0},
+		{-1, 1},
+	}
+
+	occupied := 0
+	for _, direction := range directions {
+		if board.SeesOccupiedSeat(i, j, direction) {
+			occupied += 1
+		}
+	}
+
+	return occupied
+}
+
+func (board *Board) SeesOccupiedSeat(i, j int, direction []int) bool {
+	for n := 1; ; n += 1 {
+		t_i := i + n*direction[0]
+		t_j := j + n*direction[1]
+
+		if t_i < 0 || t_i >= len(board.state) {
+			break
+		}
+
+		if t_j < 0 || t_j >= len(board.state[t_i]) {
+			break
+		}
+
+		switch board.state[t_i][t_j] {
+		case FLOOR:
+			continue
+		case EMPTY:
+			return false
+		case OCCUPIED:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (board *Board) CountOccupiedNeighbours(i, j int) int {
+	occupied := 0
+	for di := -1; di <= 1; di += 1 {
+		if i+di < 0 || i+di >= len(board.state) {
+			continue
+		}
+
+		for dj := -1; dj <= 1; dj += 1 {
+			if di == 0 && dj == 0 {
+				continue
+			}
+
+			if j+dj < 0 || j+dj >= len(board.state[i]) {
+				continue
+			}
+
+			if board.IsOccupied(i+di, j+dj) {
+				occupied += 1
+			}
+		}
+	}
+	return occupied
+}
+
+func (board *Board) SimulateSeatWith(i, j, occupied, tolerance int) State {
+	switch board.state[i][j] {
+	case FLOOR:
+		return FLOOR
+	case EMPTY:
+		if occupied > 0 {
+			return EMPTY
+		}
+		return OCCUPIED
+	case OCCUPIED:
+		if occupied > tolerance {
+			return EMPTY
+		}
+		return OCCUPIED
+	default:
+		return board.state[i][j]
+	}
+}
+
+func (board *Board) SimulateSeat(i, j int) State {
+	return board.SimulateSeatWith(i, j, board.CountOccupiedNeighbours(i, j), 3)
+}
+
+func (board *Board) SimulateSeatv2(i, j int) State {
+	return board.SimulateSeatWith(i, j, board.CountOccupiedNeighboursv2(i, j), 4)
+}
+
+func (board *Board) Simulate(simulateSeat func(board *Board, i, j int) State) int {
+
+	modifications := 0
+	newState := make([][]State, len(board.state))
+	for i := 0; i < len(board.state); i += 1 {
+		newState[i] = make([]State, len(board.state[i]))
+		for j := 0; j < len(board.state[i]); j += 1 {
+			newState[i][j] = simulateSeat(board, i, j)
+			if newState[i][j] != board.state[i][j] {
+				modifications += 1
+			}
+		}
+	}
+
+	board.state = newState
+	return modifications
+}
+
+func (board *Board) IsOccupied(i, j int) bool {
+	return board.state[i][j] == OCCUPIED
+}
+
+func ReadBoard(r io.Reader) (Board, error) {
+	grid, err := input.Grid(r, "L.")
+	if err != nil {
+		return Board{}, err
+	}
+
+	state := make([][]State, 0)
+	for _, line := range grid {
+		row := make([]State, len(line))
+		for ix, col := range line {
+			switch col {
+			case 'L':
+				row[ix] = EMPTY
+			case '.':
+				row[ix] = FLOOR
+			}
+		}
+
+		state = append(state, row)
+	}
+
+	return Board{
+		state: state,
+	}, nil
+}
+
+func CountOccupiedSeats(board *Board) int {
+	count := 0
+	for i := 0; i < len(board.state); i += 1 {
+		for j := 0; j < len(board.state[i]); j += 1 {
+			if board.IsOccupied(i, j) {
+				count += 1
+			}
+		}
+	}
+	return count
+}
+
+type Neighbourhood string
+
+const (
+	ADJACENT Neighbourhood = "adjacent"
+	VISIBLE  Neighbourhood = "visible"
+)
+
+func (neighbourhood *Neighbourhood) String() string {
+	return string(*neighbourhood)
+}
+
+func (neighbourhood *Neighbourhood) Set(value string) error {
+	switch Neighbourhood(value) {
+	case ADJACENT, VISIBLE:
+		*neighbourhood = Neighbourhood(value)
+		return nil
+	default:
+		return fmt.Errorf("neighbourhood should be %s or %s", ADJACENT, VISIBLE)
+	}
+}
+
+func (board *Board) CountOccupied(neighbourhood Neighbourhood, i, j int) int {
+	if neighbourhood == VISIBLE {
+		return board.CountOccupiedNeighboursv2(i, j)
+	}
+	return board.CountOccupiedNeighbours(i, j)
+}
+
+type Solver struct {
+	board          Board
+	neighbourhoods [2]Neighbourhood
+	tolerances     [2]int
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	s.neighbourhoods = [2]Neighbourhood{ADJACENT, VISIBLE}
+
+	params.Var(&s.neighbourhoods[0], "part1-neighbours", "seats that count as neighbours in part 1: adjacent or visible")
+	params.IntVar(&s.tolerances[0], "part1-tolerance", 3, "occupied neighbours an occupied seat tolerates in part 1")
+	params.Var(&s.neighbourhoods[1], "part2-neighbours", "seats that count as neighbours in part 2: adjacent or visible")
+	params.IntVar(&s.tolerances[1], "part2-tolerance", 4, "occupied neighbours an occupied seat tolerates in part 2")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	board, err := ReadBoard(r)
+	s.board = board
+	return err
+}
+
+func (s *Solver) solve(part int) (solver.Answer, error) {
+	neighbourhood := s.neighbourhoods[part-1]
+	tolerance := s.tolerances[part-1]
+	simulateSeat := func(board *Board, i, j int) State {
+		return board.SimulateSeatWith(i, j, board.CountOccupied(neighbourhood, i, j), tolerance)
+	}
+
+	board := s.board
+	for board.Simulate(simulateSeat) > 0 {
+	}
+
+	return solver.Answer(CountOccupiedSeats(&board)), nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return s.solve(1)
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return s.solve(2)
+}
+
+func init() {
+	solver.Register(11, func() solver.Solver { return &Solver{} })
+}
@@ -1,13 +1,13 @@
-package main
+package day12
 
 import (
+	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"io"
 	"math"
-	"os"
-	"strconv"
-	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
 )
 
 type ActionCode int
@@ -27,30 +27,23 @@ type Action struct {
 	value int
 }
 
-func readActions(filename string) ([]Action, error) {
-	bytes, err := ioutil.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
+func ReadActions(r io.Reader) ([]Action, error) {
 	actions := make([]Action, 0)
-	for _, line := range strings.Split(string(bytes), "\n") {
-		if len(line) == 0 {
-			break
-		}
 
-		action, err := readAction(line)
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		action, err := ReadAction(scanner.Text())
 		if err != nil {
-			return nil, err
+			return nil, scanner.Wrap(err)
 		}
 
 		actions = append(actions, action)
 	}
 
-	return actions, nil
+	return actions, scanner.Err()
 }
 
-func readAction(line string) (Action, error) {
+func ReadAction(line string) (Action, error) {
 
 	code := line[0]
 	action := Action{}
@@ -78,10 +71,10 @@ func readAction(line string) (Action, error) {
 		action.code = FORWARD
 		break
 	default:
-		return action, fmt.Errorf("Unknown error code: %b\n", code)
+		return action, input.Errorf(1, "Unknown action code: %c", code)
 	}
 
-	value, err := strconv.ParseInt(line[1:], 10, 32)
+	value, err := input.ParseInt(line[1:], 2, 32)
 	if err != nil {
 		return action, err
 	}
@@ -93,6 +86,7 @@ func readAction(line string) (Action, error) {
 type Navigation interface {
 	ApplyAction(action *Action)
 	Distance() int
+	Position() [2]int
 }
 
 type ShipNavigation struct {
@@ -143,6 +137,10 @@ func (navigation *ShipNavigation) Distance() int {
 		math.Abs(float64(navigation.position[1])))
 }
 
+func (navigation *ShipNavigation) Position() [2]int {
+	return navigation.position
+}
+
 func NewShipNavigation() Navigation {
 	navigation := ShipNavigation{
 		direction: [2]int{1, 0},
@@ -197,34 +195,82 @@ func (navigation *WaypointNavigation) Distance() int {
 		math.Abs(float64(navigation.position[1])))
 }
 
+func (navigation *WaypointNavigation) Position() [2]int {
+	return navigation.position
+}
+
 func NewWaypointNavigation() Navigation {
+	return NewWaypointNavigationFrom([2]int{10, 1})
+}
+
+func NewWaypointNavigationFrom(waypoint [2]int) Navigation {
 	return &WaypointNavigation{
-		waypoint: [2]int{10, 1},
+		waypoint: waypoint,
 		position: [2]int{0, 0},
 	}
 }
 
-func main() {
-	actions, err := readActions(os.Args[1])
-	if err != nil {
-		log.Fatalf("Unable to read actions: %s\n", err)
+// Vector is a flag value formatted as <east>,<north>.
+type Vector [2]int
+
+func (vector *Vector) String() string {
+	return fmt.Sprintf("%d,%d", vector[0], vector[1])
+}
+
+func (vector *Vector) Set(value string) error {
+	east, north, ok := input.ParsePair(value)
+	if !ok {
+		return fmt.Errorf("vector should be formatted as <east>,<north>: %s", value)
 	}
 
-	navigation := NewShipNavigation()
-	for _, action := range actions {
+	*vector = Vector{east, north}
+	return nil
+}
+
+type Solver struct {
+	actions  []Action
+	waypoint Vector
+	details  map[int]solver.Details
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	s.waypoint = Vector{10, 1}
+	params.Var(&s.waypoint, "waypoint", "initial waypoint of part 2, relative to the ship")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	actions, err := ReadActions(r)
+	s.actions = actions
+	return err
+}
+
+func (s *Solver) navigate(part int, navigation Navigation) (solver.Answer, error) {
+	for _, action := range s.actions {
 		navigation.ApplyAction(&action)
-		log.Printf("Navigation: %+v\n", navigation)
 	}
 
-	log.Printf("Navigation: %+v\n", navigation)
-	log.Printf("Distance: %d\n", navigation.Distance())
-
-	waypointNavigation := NewWaypointNavigation()
-	for _, action := range actions {
-		waypointNavigation.ApplyAction(&action)
-		log.Printf("Navigation: %+v\n", waypointNavigation)
+	position := navigation.Position()
+	s.details[part] = solver.Details{
+		"east":  position[0],
+		"north": position[1],
 	}
+	return solver.Answer(navigation.Distance()), nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return s.navigate(1, NewShipNavigation())
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return s.navigate(2, NewWaypointNavigationFrom(s.waypoint))
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
 
-	log.Printf("Navigation: %+v\n", waypointNavigation)
-	log.Printf("Distance: %d\n", waypointNavigation.Distance())
+func init() {
+	solver.Register(12, func() solver.Solver {
+		return &Solver{details: make(map[int]solver.Details)}
+	})
 }
@@ -0,0 +1,190 @@
+package day13
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type Note struct {
+	departure int64
+	busLines  []int64
+}
+
+func ReadNote(r io.Reader) (Note, error) {
+	scanner := input.NewScanner(r)
+	if !scanner.Scan() {
+		return Note{}, scanner.Wrap(errors.New("Note should have at least 2 lines"))
+	}
+
+	departure, err := input.ParseInt(scanner.Text(), 1, 64)
+	if err != nil {
+		return Note{}, scanner.Wrap(err)
+	}
+
+	if !scanner.Scan() {
+		return Note{}, scanner.Wrap(errors.New("Note should have at least 2 lines"))
+	}
+
+	busLines := make([]int64, 0)
+	column := 1
+	for _, id := range strings.Split(scanner.Text(), ",") {
+		if id == "x" {
+			busLines = append(busLines, 0)
+		} else {
+			busLine, err := input.ParseInt(id, column, 32)
+			if err != nil {
+				return Note{}, scanner.Wrap(err)
+			}
+			if busLine < 1 {
+				return Note{}, scanner.Wrap(input.Errorf(column, "Bus line %d should be positive", busLine))
+			}
+
+			busLines = append(busLines, busLine)
+		}
+
+		column += len(id) + 1
+	}
+
+	return Note{
+		departure: departure,
+		busLines:  busLines,
+	}, scanner.Err()
+}
+
+type Departure struct {
+	time    int64
+	busLine int64
+}
+
+func FindMinDeparture(departure int64, busLine int64) Departure {
+	remainder := departure % busLine
+
+	if remainder == 0 {
+		remainder = busLine
+	}
+
+	return Departure{
+		busLine: busLine,
+		time:    departure + (busLine - remainder),
+	}
+}
+
+type BusDeparture struct {
+	busLine int64
+	offset  int64
+}
+
+func FindEarliestDeparture(note Note) Departure {
+	minDeparture := Departure{time: -1}
+	for _, busLine := range note.busLines {
+		if busLine == 0 {
+			continue
+		}
+
+		departure := FindMinDeparture(note.departure, busLine)
+		if minDeparture.time == -1 || departure.time < minDeparture.time {
+			minDeparture = departure
+		}
+	}
+
+	return minDeparture
+}
+
+func FindBusDepartures(note Note) []BusDeparture {
+	departures := make([]BusDeparture, 0)
+	for offset, busLine := range note.busLines {
+		if busLine != 0 {
+			departures = append(departures, BusDeparture{
+				offset:  int64(offset),
+				busLine: busLine,
+			})
+		}
+	}
+	return departures
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// CheckCoprime returns an error unless the bus lines of departures are
+// pairwise coprime, which FindAlignedTimestamp relies on to terminate.
+func CheckCoprime(departures []BusDeparture) error {
+	for ix, first := range departures {
+		for _, second := range departures[ix+1:] {
+			if gcd(first.busLine, second.busLine) != 1 {
+				return fmt.Errorf("Bus lines %d and %d are not coprime", first.busLine, second.busLine)
+			}
+		}
+	}
+	return nil
+}
+
+func FindAlignedTimestamp(departures []BusDeparture) int64 {
+	timestamp := int64(0)
+	inc := departures[0].busLine
+
+	for ix := 1; ix < len(departures); ix += 1 {
+		for (timestamp+departures[ix].offset)%departures[ix].busLine != 0 {
+			timestamp += inc
+		}
+
+		inc *= departures[ix].busLine
+	}
+
+	return timestamp
+}
+
+type Solver struct {
+	note    Note
+	details map[int]solver.Details
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	note, err := ReadNote(r)
+	s.note = note
+	return err
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	minDeparture := FindEarliestDeparture(s.note)
+	if minDeparture.time == -1 {
+		return 0, errors.New("Note does not list any bus lines")
+	}
+
+	s.details[1] = solver.Details{
+		"bus_line":  minDeparture.busLine,
+		"departure": minDeparture.time,
+	}
+	return solver.Answer((minDeparture.time - s.note.departure) * minDeparture.busLine), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	departures := FindBusDepartures(s.note)
+	if len(departures) == 0 {
+		return 0, errors.New("Note does not list any bus lines")
+	}
+	if err := CheckCoprime(departures); err != nil {
+		return 0, err
+	}
+
+	return solver.Answer(FindAlignedTimestamp(departures)), nil
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
+
+func init() {
+	solver.Register(13, func() solver.Solver {
+		return &Solver{details: make(map[int]solver.Details)}
+	})
+}
@@ -0,0 +1,102 @@
+package day2
+
+import (
+	"fmt"
+)
+
+func (policy Policy) String() string {
+	return fmt.Sprintf("%d-%d %s", policy.MinCount, policy.MaxCount, policy.Character())
+}
+
+type AuditEntry struct {
+	Index    int     `json:"index"`
+	Line     int     `json:"line,omitempty"`
+	Policy   string  `json:"policy"`
+	Password string  `json:"password"`
+	Verdict  Verdict `json:"verdict"`
+	Repair   *Repair `json:"repair,omitempty"`
+}
+
+type ReasonCount struct {
+	Reason Reason `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// AuditReport holds the verdict for every entry, along with the number of
+// invalid entries per reason, most frequent first.
+type AuditReport struct {
+	Entries []AuditEntry  `json:"entries"`
+	Valid   int           `json:"valid"`
+	Invalid int           `json:"invalid"`
+	Reasons []ReasonCount `json:"reasons"`
+}
+
+func NewAuditReport() *AuditReport {
+	return &AuditReport{
+		Entries: make([]AuditEntry, 0),
+		Reasons: make([]ReasonCount, 0),
+	}
+}
+
+// Tally adds verdict to the totals of the report without recording an entry,
+// which suits reports over more entries than fit in memory.
+func (report *AuditReport) Tally(verdict Verdict) {
+	if verdict.Valid {
+		report.Valid += 1
+		return
+	}
+	report.Invalid += 1
+
+	ix := 0
+	for ix < len(report.Reasons) && report.Reasons[ix].Reason != verdict.Reason {
+		ix += 1
+	}
+	if ix == len(report.Reasons) {
+		report.Reasons = append(report.Reasons, ReasonCount{Reason: verdict.Reason})
+	}
+	report.Reasons[ix].Count += 1
+
+	for ; ix > 0; ix -= 1 {
+		previous, current := report.Reasons[ix-1], report.Reasons[ix]
+		if previous.Count > current.Count || (previous.Count == current.Count && previous.Reason < current.Reason) {
+			break
+		}
+		report.Reasons[ix-1], report.Reasons[ix] = current, previous
+	}
+}
+
+func Audit(entries []PasswordEntry, rule Rule) *AuditReport {
+	report := NewAuditReport()
+	for ix, entry := range entries {
+		verdict := rule(entry)
+		report.Tally(verdict)
+		report.Entries = append(report.Entries, AuditEntry{
+			Index:    ix + 1,
+			Policy:   entry.Policy.String(),
+			Password: entry.Password,
+			Verdict:  verdict,
+		})
+	}
+	return report
+}
+
+// SuggestRepair returns a repair for an entry that does not satisfy both the
+// count and the positions policy, or nil when the entry satisfies both or
+// cannot be repaired.
+func SuggestRepair(entry PasswordEntry, units Units) *Repair {
+	if checkCount(entry, units).Valid && checkPositions(entry, units).Valid {
+		return nil
+	}
+
+	repair, err := RepairPassword(entry, units)
+	if err != nil {
+		return nil
+	}
+	return &repair
+}
+
+func (report *AuditReport) SuggestRepairs(entries []PasswordEntry, units Units) {
+	for ix, entry := range entries {
+		report.Entries[ix].Repair = SuggestRepair(entry, units)
+	}
+}
@@ -0,0 +1,107 @@
+package day2
+
+import (
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+// Policy constrains a single character of a password. Cluster holds the
+// character as written when it is a grapheme cluster of several runes, in
+// which case Char is its first rune.
+type Policy struct {
+	MinCount int
+	MaxCount int
+	Char     rune
+	Cluster  string
+}
+
+// Character returns the character of the policy as a string, which is a
+// whole grapheme cluster when the policy was written with one.
+func (policy Policy) Character() string {
+	if policy.Cluster != "" {
+		return policy.Cluster
+	}
+	return string(policy.Char)
+}
+
+type PasswordEntry struct {
+	Policy   Policy
+	Password string
+}
+
+func ReadEntries(r io.Reader) ([]PasswordEntry, error) {
+	entries := make([]PasswordEntry, 0)
+
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		entry, err := ReadEntry(scanner.Text())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+func CountValidEntries(entries []PasswordEntry, isValid func(PasswordEntry) bool) int {
+	count := 0
+	for _, entry := range entries {
+		if isValid(entry) {
+			count += 1
+		}
+	}
+	return count
+}
+
+func IsValid(entry PasswordEntry) bool {
+	return CheckCount(entry).Valid
+}
+
+func IsValidUpdated(entry PasswordEntry) bool {
+	return CheckPositions(entry).Valid
+}
+
+type Solver struct {
+	entries     []PasswordEntry
+	part1Policy string
+	part2Policy string
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	usage := "policy passwords should satisfy, one of " + strings.Join(Policies(), ", ")
+	params.StringVar(&s.part1Policy, "part1-policy", "count", usage+" in part 1")
+	params.StringVar(&s.part2Policy, "part2-policy", "positions", usage+" in part 2")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	entries, err := ReadEntries(r)
+	s.entries = entries
+	return err
+}
+
+func (s *Solver) solve(policy string) (solver.Answer, error) {
+	rule, err := ParsePolicy(policy)
+	if err != nil {
+		return 0, err
+	}
+
+	return solver.Answer(CountValidEntries(s.entries, rule.IsValid)), nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return s.solve(s.part1Policy)
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return s.solve(s.part2Policy)
+}
+
+func init() {
+	solver.Register(2, func() solver.Solver { return &Solver{} })
+}
@@ -0,0 +1,415 @@
+package day2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+func TestReadEntryUnicode(t *testing.T) {
+	cases := []struct {
+		line    string
+		char    rune
+		cluster string
+	}{
+		{"1-3 é: café", 'é', ""},
+		{"1-3 ß: straße", 'ß', ""},
+		{"2-4 日: 日本日記", '日', ""},
+		{"1-2 e\u0301: cafe\u0301", 'e', "e\u0301"},
+		{"1-1 🇧🇪: 🇧🇪🇳🇱", 0x1f1e7, "🇧🇪"},
+	}
+
+	for _, c := range cases {
+		entry, err := ReadEntry(c.line)
+		if err != nil {
+			t.Errorf("%q: %s", c.line, err)
+			continue
+		}
+
+		if entry.Policy.Char != c.char || entry.Policy.Cluster != c.cluster {
+			t.Errorf("%q: expected %q/%q, got %q/%q", c.line, c.char, c.cluster, entry.Policy.Char, entry.Policy.Cluster)
+		}
+	}
+
+	if _, err := ReadEntry("1-3 ab: abc"); err == nil {
+		t.Errorf("expected an error for a policy with two characters")
+	}
+}
+
+func TestSplitGraphemes(t *testing.T) {
+	cases := []struct {
+		text     string
+		clusters []string
+	}{
+		{"abc", []string{"a", "b", "c"}},
+		{"café", []string{"c", "a", "f", "é"}},
+		{"cafe\u0301", []string{"c", "a", "f", "e\u0301"}},
+		{"🇧🇪🇳🇱", []string{"🇧🇪", "🇳🇱"}},
+		{"👍🏽!", []string{"👍🏽", "!"}},
+		{"👩\u200d💻x", []string{"👩\u200d💻", "x"}},
+		{"", []string{}},
+	}
+
+	for _, c := range cases {
+		if clusters := SplitGraphemes(c.text); !reflect.DeepEqual(clusters, c.clusters) {
+			t.Errorf("%q: expected %q, got %q", c.text, c.clusters, clusters)
+		}
+	}
+}
+
+func TestPoliciesUnicode(t *testing.T) {
+	cases := []struct {
+		line      string
+		count     bool
+		positions bool
+		units     Units
+	}{
+		// Counted as bytes, é would match the first byte of every é.
+		{"1-2 é: ééé", false, false, Runes},
+		{"3-3 é: ééé", true, false, Runes},
+		{"1-3 é: éaé", true, false, Runes},
+		{"1-2 é: aéb", true, true, Runes},
+		{"1-2 ü: üxü", true, true, Runes},
+		{"1-3 日: 日本語", true, true, Runes},
+		{"1-3 語: 日本語", true, true, Runes},
+		// A combining acute accent makes é a different character than e.
+		{"1-1 e: cafe\u0301", true, false, Runes},
+		// Counting runes, a policy written with a cluster matches its first rune.
+		{"1-1 e\u0301: cafe\u0301", true, false, Runes},
+		{"1-4 e\u0301: cafe\u0301", true, true, Runes},
+		{"1-1 e: cafe\u0301", false, false, Graphemes},
+		{"1-4 e\u0301: cafe\u0301", true, true, Graphemes},
+		{"1-4 é: cafe\u0301", false, false, Graphemes},
+		{"1-2 🇧🇪: 🇳🇱🇧🇪", true, true, Graphemes},
+		{"1-2 👍: 👍🏽👍", true, true, Graphemes},
+	}
+
+	for _, c := range cases {
+		entry, err := ReadEntry(c.line)
+		if err != nil {
+			t.Errorf("%q: %s", c.line, err)
+			continue
+		}
+
+		count, positions := CheckCount(entry), CheckPositions(entry)
+		if c.units == Graphemes {
+			count, positions = CheckCountGraphemes(entry), CheckPositionsGraphemes(entry)
+		}
+
+		if count.Valid != c.count {
+			t.Errorf("%q: expected count %v in %s, got %s", c.line, c.count, c.units, count)
+		}
+		if positions.Valid != c.positions {
+			t.Errorf("%q: expected positions %v in %s, got %s", c.line, c.positions, c.units, positions)
+		}
+	}
+}
+
+func TestForbidPositionsCountRunes(t *testing.T) {
+	rule, err := ParsePolicy("forbid:ß")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verdict := rule(PasswordEntry{Password: "éèßa"})
+	if verdict.Valid || !reflect.DeepEqual(verdict.Positions, []int{3}) {
+		t.Errorf("expected ß at position 3, got %s", verdict)
+	}
+}
+
+func TestRepairPassword(t *testing.T) {
+	cases := []struct {
+		line  string
+		edits int
+		units Units
+	}{
+		{"1-3 a: abcde", 0, Runes},
+		{"1-3 b: cdefg", 1, Runes},
+		{"2-9 c: ccccccccc", 1, Runes},
+		{"3-5 z: zzzzzzzz", 3, Runes},
+		{"2-4 q: ", 2, Runes},
+		{"1-2 é: ééé", 1, Runes},
+		{"1-3 é: café", 1, Graphemes},
+	}
+
+	for _, c := range cases {
+		entry, err := ReadEntry(c.line)
+		if err != nil {
+			t.Errorf("%q: %s", c.line, err)
+			continue
+		}
+
+		repair, err := RepairPassword(entry, c.units)
+		if err != nil {
+			t.Errorf("%q: %s", c.line, err)
+			continue
+		}
+
+		repaired := entry
+		repaired.Password = repair.Password
+		if !checkCount(repaired, c.units).Valid || !checkPositions(repaired, c.units).Valid {
+			t.Errorf("%q: repaired password %q is not valid", c.line, repair.Password)
+		}
+		if len(repair.Edits) != c.edits {
+			t.Errorf("%q: expected %d edits, got %v", c.line, c.edits, repair.Edits)
+		}
+	}
+
+	if _, err := RepairPassword(PasswordEntry{Policy: Policy{MinCount: 2, MaxCount: 2, Char: 'a'}}, Runes); err == nil {
+		t.Errorf("expected an error when both positions are the same")
+	}
+}
+
+func TestReadEntry(t *testing.T) {
+	cases := []struct {
+		line  string
+		entry PasswordEntry
+	}{
+		{"1-3 a: abcde", PasswordEntry{Policy{1, 3, 'a', ""}, "abcde"}},
+		{"1-3 a: ab:cd", PasswordEntry{Policy{1, 3, 'a', ""}, "ab:cd"}},
+		{"1-3 a: a b c", PasswordEntry{Policy{1, 3, 'a', ""}, "a b c"}},
+		{"1-3 a: ", PasswordEntry{Policy{1, 3, 'a', ""}, ""}},
+		{"1-3 a:", PasswordEntry{Policy{1, 3, 'a', ""}, ""}},
+		{"1-3 a:abc", PasswordEntry{Policy{1, 3, 'a', ""}, "abc"}},
+		{"2-2 :: ::", PasswordEntry{Policy{2, 2, ':', ""}, "::"}},
+		{"10-12 -:  x", PasswordEntry{Policy{10, 12, '-', ""}, " x"}},
+	}
+
+	for _, c := range cases {
+		entry, err := ReadEntry(c.line)
+		if err != nil {
+			t.Errorf("%q: %s", c.line, err)
+		} else if entry != c.entry {
+			t.Errorf("%q: expected %+v, got %+v", c.line, c.entry, entry)
+		}
+	}
+}
+
+func TestReadEntryErrors(t *testing.T) {
+	cases := []struct {
+		line   string
+		column int
+	}{
+		{"", 1},
+		{"a-3 a: abc", 1},
+		{"-1-3 a: abc", 1},
+		{"0-3 a: abc", 1},
+		{"1-0 a: abc", 3},
+		{"3-1 a: abc", 3},
+		{"1 3 a: abc", 2},
+		{"1-3a: abc", 4},
+		{"1-3  a: abc", 5},
+		{"1-3 : abc", 6},
+		{"1-3 ab: abc", 6},
+		{"1-3 a", 6},
+		{"1-99999999999 a: abc", 3},
+	}
+
+	for _, c := range cases {
+		_, err := ReadEntry(c.line)
+		parseError, ok := err.(*input.ParseError)
+		if !ok {
+			t.Errorf("%q: expected a parse error, got %v", c.line, err)
+		} else if parseError.Column != c.column {
+			t.Errorf("%q: expected an error at column %d, got %d: %s", c.line, c.column, parseError.Column, err)
+		}
+	}
+}
+
+func generateEntries(count int) string {
+	var text strings.Builder
+	for ix := 0; ix < count; ix += 1 {
+		min := ix%5 + 1
+		fmt.Fprintf(&text, "%d-%d %c: %s\n", min, min+ix%7, 'a'+ix%3, strings.Repeat("abc", ix%11))
+	}
+	return text.String()
+}
+
+func TestValidateStream(t *testing.T) {
+	text := generateEntries(10000)
+	entries, err := ReadEntries(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{1, 4, 16} {
+		next := 1
+		valid := 0
+		err := ValidateStream(context.Background(), strings.NewReader(text), CheckCount,
+			StreamOptions{Workers: workers, BatchSize: 7}, func(result StreamResult) error {
+				if result.Index != next || result.Line != next || result.Entry != entries[next-1] {
+					t.Fatalf("%d workers: expected entry %d, got %d", workers, next, result.Index)
+				}
+				if result.Verdict.Valid {
+					valid += 1
+				}
+				next += 1
+				return nil
+			})
+
+		if err != nil {
+			t.Fatalf("%d workers: %s", workers, err)
+		}
+		if expected := CountValidEntries(entries, IsValid); valid != expected || next != len(entries)+1 {
+			t.Errorf("%d workers: expected %d valid entries, got %d", workers, expected, valid)
+		}
+	}
+}
+
+func TestValidateStreamErrors(t *testing.T) {
+	text := generateEntries(500) + "1-3 ab: abc\n" + generateEntries(500)
+
+	emitted := 0
+	err := ValidateStream(context.Background(), strings.NewReader(text), CheckCount,
+		StreamOptions{Workers: 4, BatchSize: 3}, func(result StreamResult) error {
+			emitted += 1
+			return nil
+		})
+
+	parseError, ok := err.(*input.ParseError)
+	if !ok || parseError.Line != 501 || emitted != 500 {
+		t.Errorf("expected an error on line 501 after 500 entries, got %v after %d", err, emitted)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	emitted = 0
+	err = ValidateStream(ctx, strings.NewReader(generateEntries(10000)), CheckCount,
+		StreamOptions{Workers: 4, BatchSize: 3}, func(result StreamResult) error {
+			emitted += 1
+			if emitted == 100 {
+				cancel()
+			}
+			return nil
+		})
+
+	if err != context.Canceled || emitted >= 10000 {
+		t.Errorf("expected cancellation, got %v after %d entries", err, emitted)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	cases := []struct {
+		policy string
+		line   string
+		valid  bool
+		reason Reason
+	}{
+		{"count", "1-3 a: abcde", true, ""},
+		{"positions", "1-3 a: abade", false, BothPositionsMatch},
+		{"all(count, not(forbid:abc))", "1-3 a: abcde", true, ""},
+		{"all(count, not(forbid:abc))", "1-3 a: aXbc", false, Negated},
+		{"all(count,not(forbid:abc))", "2-3 b: abc", false, TooFew},
+		{"any(positions, min-length:10)", "1-3 a: abade", false, NoAlternative},
+		{"any(positions, min-length:5)", "1-3 a: abade", true, ""},
+		{`regex:"a,b"`, "1-1 x: xa,by", true, ""},
+		{`regex:"a,b"`, "1-1 x: xab", false, NoMatch},
+		{`all(regex:"[)]", count)`, "1-1 x: x)", true, ""},
+		{"classes:lower+digit", "1-1 x: x1", true, ""},
+		{"classes:lower+digit", "1-1 x: xy", false, MissingClass},
+		{"not(not(count))", "1-1 x: xx", false, Negated},
+	}
+
+	for _, c := range cases {
+		rule, err := ParsePolicy(c.policy)
+		if err != nil {
+			t.Errorf("%s: %s", c.policy, err)
+			continue
+		}
+
+		entry, err := ReadEntry(c.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if verdict := rule(entry); verdict.Valid != c.valid || verdict.Reason != c.reason {
+			t.Errorf("%s on %q: expected %v (%s), got %s", c.policy, c.line, c.valid, c.reason, verdict)
+		}
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	cases := []struct {
+		policy   string
+		position int
+		message  string
+	}{
+		{"", 1, "unknown policy"},
+		{"bogus", 1, "unknown policy \"bogus\""},
+		{"all(count, bogus)", 12, "unknown policy \"bogus\""},
+		{`regex:"a,b`, 7, "unterminated string"},
+		{`all(regex:"a,b, count)`, 11, "unterminated string"},
+		{`regex:"a\q"`, 7, "invalid string"},
+		{"not(count, positions)", 4, "expects a single policy, got 2"},
+		{"not()", 5, "unknown policy"},
+		{"all count", 4, "expects a list of policies"},
+		{"all(count", 10, "missing )"},
+		{"all(count; positions)", 10, "expected , or )"},
+		{"count extra", 7, "unexpected \"extra\""},
+		{"count:bytes", 1, "count: expects runes or graphemes"},
+		{"min-length:x", 1, "invalid length"},
+		{"all(count, forbid:)", 12, "expects a substring"},
+	}
+
+	for _, c := range cases {
+		_, err := ParsePolicy(c.policy)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.policy)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), fmt.Sprintf(" at %d: ", c.position)) || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%q: expected %q at %d, got %s", c.policy, c.message, c.position, err)
+		}
+	}
+}
+
+func TestTally(t *testing.T) {
+	reasons := []Reason{Forbidden, TooMany, "", TooMany, TooFew, NoMatch, NoMatch, TooFew, "", NoMatch}
+
+	report := NewAuditReport()
+	for _, reason := range reasons {
+		report.Tally(Verdict{Valid: reason == "", Reason: reason})
+	}
+
+	expected := []ReasonCount{{NoMatch, 3}, {TooFew, 2}, {TooMany, 2}, {Forbidden, 1}}
+	if report.Valid != 2 || report.Invalid != 8 || !reflect.DeepEqual(report.Reasons, expected) {
+		t.Errorf("expected 2 valid, 8 invalid with %v, got %d, %d with %v", expected, report.Valid, report.Invalid, report.Reasons)
+	}
+}
+
+func TestVerdictString(t *testing.T) {
+	cases := []struct {
+		verdict  Verdict
+		expected string
+	}{
+		{Verdict{Valid: true, Reason: TooFew}, "valid"},
+		{Verdict{Reason: TooFew, Detail: "a", Count: 0, Min: 1, Max: 3}, `too few occurrences of "a": 0, expected 1-3`},
+		{Verdict{Reason: BothPositionsMatch, Detail: "é", Min: 1, Max: 3, Positions: []int{1, 3}}, `both positions match of 1 and 3 for "é", matching [1 3]`},
+		{Verdict{Reason: TooShort, Count: 4, Min: 8}, "too short: 4 characters, expected at least 8"},
+		{Verdict{Reason: NoAlternative, Causes: []Verdict{{Policy: "count"}, {Policy: "regex"}}}, "satisfies none of the alternatives: count, regex"},
+		{Verdict{Reason: Forbidden, Detail: "abc", Positions: []int{2}}, `contains forbidden substring "abc" at [2]`},
+		{Verdict{Reason: MissingClass, Detail: "digit"}, `missing character class "digit"`},
+	}
+
+	for _, c := range cases {
+		if text := c.verdict.String(); text != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, text)
+		}
+	}
+
+	entry, err := ReadEntry("1-3 a: abcde")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := CheckPositions(entry).String(); text != "valid" {
+		t.Errorf("expected valid, got %q", text)
+	}
+	entry.Password = "bcde"
+	if text := CheckCount(entry).String(); text != `too few occurrences of "a": 0, expected 1-3` {
+		t.Errorf("unexpected verdict %q", text)
+	}
+}
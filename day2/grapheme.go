@@ -0,0 +1,99 @@
+package day2
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1f3fb && r <= 0x1f3ff
+}
+
+// extends reports whether r continues the grapheme cluster before it.
+func extends(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector) ||
+		isEmojiModifier(r) || r == zeroWidthJoiner
+}
+
+// SplitGraphemes splits text into grapheme clusters: a character along with the
+// combining marks, variation selectors and emoji modifiers that follow it,
+// characters joined by a zero width joiner and pairs of regional indicators
+// forming a flag. This covers the user-perceived characters found in
+// passwords without the full Unicode segmentation rules.
+func SplitGraphemes(text string) []string {
+	clusters := make([]string, 0, len(text))
+	for start := 0; start < len(text); {
+		first, size := utf8.DecodeRuneInString(text[start:])
+		end := start + size
+		previous := first
+
+		if isRegionalIndicator(first) && end < len(text) {
+			if r, size := utf8.DecodeRuneInString(text[end:]); isRegionalIndicator(r) {
+				end += size
+			}
+		}
+
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !extends(r) && previous != zeroWidthJoiner {
+				break
+			}
+			end += size
+			previous = r
+		}
+
+		clusters = append(clusters, text[start:end])
+		start = end
+	}
+	return clusters
+}
+
+// Units selects what policies consider a character: a single rune or a
+// grapheme cluster as split by SplitGraphemes.
+type Units int
+
+const (
+	Runes Units = iota
+	Graphemes
+)
+
+func (units Units) String() string {
+	if units == Graphemes {
+		return "graphemes"
+	}
+	return "runes"
+}
+
+func (units Units) Split(text string) []string {
+	if units == Graphemes {
+		return SplitGraphemes(text)
+	}
+
+	characters := make([]string, 0, len(text))
+	for _, r := range text {
+		characters = append(characters, string(r))
+	}
+	return characters
+}
+
+// Character returns the character of policy as a single unit: its first rune
+// when counting runes, even if the policy was written with a cluster of
+// several, and its whole cluster when counting graphemes.
+func (units Units) Character(policy Policy) string {
+	if units == Graphemes {
+		return policy.Character()
+	}
+	return string(policy.Char)
+}
+
+// Index returns the 1-based position of the character at byte offset in
+// text, counted in units.
+func (units Units) Index(text string, offset int) int {
+	return len(units.Split(text[:offset])) + 1
+}
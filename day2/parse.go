@@ -0,0 +1,122 @@
+package day2
+
+import (
+	"unicode/utf8"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+// entryParser reads a line of the password database:
+//
+//	entry    = position "-" position " " char ":" [" "] password
+//	position = digit {digit}
+//
+// The char is a single grapheme cluster other than a space and the password
+// is the rest of the line, which may be empty or hold colons and spaces.
+// Columns in errors are 1-based byte offsets into the line.
+type entryParser struct {
+	line string
+	pos  int
+}
+
+func (p *entryParser) errorf(format string, args ...interface{}) error {
+	return input.Errorf(p.pos+1, format, args...)
+}
+
+func (p *entryParser) describe() string {
+	if p.pos >= len(p.line) {
+		return "end of line"
+	}
+
+	r, _ := utf8.DecodeRuneInString(p.line[p.pos:])
+	return "'" + string(r) + "'"
+}
+
+func (p *entryParser) expect(b byte, what string) error {
+	if p.pos >= len(p.line) || p.line[p.pos] != b {
+		return p.errorf("Expected %s, got %s", what, p.describe())
+	}
+
+	p.pos += 1
+	return nil
+}
+
+func (p *entryParser) position(name string) (int, error) {
+	start := p.pos
+	for p.pos < len(p.line) && p.line[p.pos] >= '0' && p.line[p.pos] <= '9' {
+		p.pos += 1
+	}
+
+	if p.pos == start {
+		return 0, p.errorf("Expected the %s position, got %s", name, p.describe())
+	}
+
+	value, err := input.ParseInt(p.line[start:p.pos], start+1, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	if value == 0 {
+		return 0, input.Errorf(start+1, "The %s position should be at least 1", name)
+	}
+	return int(value), nil
+}
+
+func (p *entryParser) char() (rune, string, error) {
+	if p.pos >= len(p.line) || p.line[p.pos] == ' ' {
+		return 0, "", p.errorf("Expected the policy character, got %s", p.describe())
+	}
+
+	cluster := SplitGraphemes(p.line[p.pos:])[0]
+	p.pos += len(cluster)
+
+	char, _ := utf8.DecodeRuneInString(cluster)
+	if utf8.RuneCountInString(cluster) == 1 {
+		cluster = ""
+	}
+	return char, cluster, nil
+}
+
+func (p *entryParser) entry() (PasswordEntry, error) {
+	var entry PasswordEntry
+	var err error
+
+	if entry.Policy.MinCount, err = p.position("first"); err != nil {
+		return entry, err
+	}
+	if err = p.expect('-', "'-'"); err != nil {
+		return entry, err
+	}
+
+	maxStart := p.pos
+	if entry.Policy.MaxCount, err = p.position("second"); err != nil {
+		return entry, err
+	}
+	if entry.Policy.MinCount > entry.Policy.MaxCount {
+		return entry, input.Errorf(maxStart+1, "The second position %d is before the first position %d",
+			entry.Policy.MaxCount, entry.Policy.MinCount)
+	}
+
+	if err = p.expect(' ', "a space"); err != nil {
+		return entry, err
+	}
+	if entry.Policy.Char, entry.Policy.Cluster, err = p.char(); err != nil {
+		return entry, err
+	}
+	if err = p.expect(':', "':' after the policy character"); err != nil {
+		return entry, err
+	}
+
+	if p.pos < len(p.line) && p.line[p.pos] == ' ' {
+		p.pos += 1
+	}
+	entry.Password = p.line[p.pos:]
+
+	return entry, nil
+}
+
+// ReadEntry parses a line formatted as <min>-<max> <char>: <password>.
+func ReadEntry(line string) (PasswordEntry, error) {
+	p := &entryParser{line: line}
+	return p.entry()
+}
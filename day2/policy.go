@@ -0,0 +1,369 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule decides whether the password of an entry is valid.
+type Rule func(entry PasswordEntry) Verdict
+
+func (rule Rule) IsValid(entry PasswordEntry) bool {
+	return rule(entry).Valid
+}
+
+// RuleFactory creates a rule from the argument following the name of a
+// policy, or from the rules listed in parentheses for combinators.
+type RuleFactory struct {
+	Arg     func(arg string) (Rule, error)
+	Combine func(rules []Rule) (Rule, error)
+}
+
+var policies = make(map[string]RuleFactory)
+
+func RegisterPolicy(name string, factory RuleFactory) {
+	if _, ok := policies[name]; ok {
+		panic(fmt.Sprintf("day2: policy %s registered twice", name))
+	}
+
+	policies[name] = factory
+}
+
+func Policies() []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// unitRule selects the rule for the units named by arg, which defaults to
+// runes.
+func unitRule(runes, graphemes Rule) func(string) (Rule, error) {
+	return func(arg string) (Rule, error) {
+		switch arg {
+		case "", Runes.String():
+			return runes, nil
+		case Graphemes.String():
+			return graphemes, nil
+		default:
+			return nil, fmt.Errorf("expects %s or %s, got %q", Runes, Graphemes, arg)
+		}
+	}
+}
+
+// AllOf is valid when every rule is, and otherwise returns the verdict of
+// the first rule that is not.
+func AllOf(rules ...Rule) Rule {
+	return func(entry PasswordEntry) Verdict {
+		for _, rule := range rules {
+			if verdict := rule(entry); !verdict.Valid {
+				return verdict
+			}
+		}
+		return Verdict{Valid: true, Policy: "all"}
+	}
+}
+
+// AnyOf returns the verdict of the first valid rule, or the verdicts of all
+// rules when none is.
+func AnyOf(rules ...Rule) Rule {
+	return func(entry PasswordEntry) Verdict {
+		causes := make([]Verdict, 0, len(rules))
+		for _, rule := range rules {
+			verdict := rule(entry)
+			if verdict.Valid {
+				return verdict
+			}
+			causes = append(causes, verdict)
+		}
+		return Verdict{Policy: "any", Reason: NoAlternative, Causes: causes}
+	}
+}
+
+func Not(rule Rule) Rule {
+	return func(entry PasswordEntry) Verdict {
+		verdict := rule(entry)
+		if !verdict.Valid {
+			return Verdict{Valid: true, Policy: "not", Causes: []Verdict{verdict}}
+		}
+		return Verdict{Policy: "not", Reason: Negated, Causes: []Verdict{verdict}}
+	}
+}
+
+func Regex(pattern string) (Rule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(entry PasswordEntry) Verdict {
+		verdict := Verdict{Valid: true, Policy: "regex", Detail: pattern}
+		if location := re.FindStringIndex(entry.Password); location != nil {
+			verdict.Count = 1
+			verdict.Positions = []int{Runes.Index(entry.Password, location[0])}
+		} else {
+			verdict.Valid = false
+			verdict.Reason = NoMatch
+		}
+		return verdict
+	}, nil
+}
+
+func MinLength(length int) Rule {
+	return func(entry PasswordEntry) Verdict {
+		verdict := Verdict{Valid: true, Policy: "min-length", Count: utf8.RuneCountInString(entry.Password), Min: length}
+		if verdict.Count < length {
+			verdict.Valid = false
+			verdict.Reason = TooShort
+		}
+		return verdict
+	}
+}
+
+var characterClasses = map[string]func(rune) bool{
+	"lower":  unicode.IsLower,
+	"upper":  unicode.IsUpper,
+	"letter": unicode.IsLetter,
+	"digit":  unicode.IsDigit,
+	"punct":  unicode.IsPunct,
+	"symbol": unicode.IsSymbol,
+	"space":  unicode.IsSpace,
+}
+
+// CharacterClasses requires at least one character of every class, which is
+// one of lower, upper, letter, digit, punct, symbol and space.
+func CharacterClasses(classes ...string) (Rule, error) {
+	predicates := make([]func(rune) bool, len(classes))
+	for ix, class := range classes {
+		predicate, ok := characterClasses[class]
+		if !ok {
+			return nil, fmt.Errorf("unknown character class %q", class)
+		}
+		predicates[ix] = predicate
+	}
+
+	return func(entry PasswordEntry) Verdict {
+		for ix, predicate := range predicates {
+			if strings.IndexFunc(entry.Password, predicate) < 0 {
+				return Verdict{Policy: "classes", Reason: MissingClass, Detail: classes[ix]}
+			}
+		}
+		return Verdict{Valid: true, Policy: "classes", Count: len(classes)}
+	}, nil
+}
+
+func Forbid(substrings ...string) Rule {
+	return func(entry PasswordEntry) Verdict {
+		for _, substring := range substrings {
+			if ix := strings.Index(entry.Password, substring); ix >= 0 {
+				return Verdict{
+					Policy:    "forbid",
+					Reason:    Forbidden,
+					Detail:    substring,
+					Count:     strings.Count(entry.Password, substring),
+					Positions: []int{Runes.Index(entry.Password, ix)},
+				}
+			}
+		}
+		return Verdict{Valid: true, Policy: "forbid"}
+	}
+}
+
+func init() {
+	RegisterPolicy("count", RuleFactory{Arg: unitRule(CheckCount, CheckCountGraphemes)})
+	RegisterPolicy("positions", RuleFactory{Arg: unitRule(CheckPositions, CheckPositionsGraphemes)})
+	RegisterPolicy("regex", RuleFactory{Arg: Regex})
+	RegisterPolicy("min-length", RuleFactory{Arg: func(arg string) (Rule, error) {
+		length, err := strconv.Atoi(arg)
+		if err != nil || length < 0 {
+			return nil, fmt.Errorf("invalid length %q", arg)
+		}
+		return MinLength(length), nil
+	}})
+	RegisterPolicy("classes", RuleFactory{Arg: func(arg string) (Rule, error) {
+		return CharacterClasses(strings.Split(arg, "+")...)
+	}})
+	RegisterPolicy("forbid", RuleFactory{Arg: func(arg string) (Rule, error) {
+		if arg == "" {
+			return nil, errors.New("expects a substring")
+		}
+		return Forbid(arg), nil
+	}})
+	RegisterPolicy("all", RuleFactory{Combine: func(rules []Rule) (Rule, error) {
+		return AllOf(rules...), nil
+	}})
+	RegisterPolicy("any", RuleFactory{Combine: func(rules []Rule) (Rule, error) {
+		return AnyOf(rules...), nil
+	}})
+	RegisterPolicy("not", RuleFactory{Combine: func(rules []Rule) (Rule, error) {
+		if len(rules) != 1 {
+			return nil, fmt.Errorf("expects a single policy, got %d", len(rules))
+		}
+		return Not(rules[0]), nil
+	}})
+}
+
+// policyParser reads policy expressions, which are either a policy name with
+// an optional argument, as in "min-length:8", or a combinator applied to a
+// list of expressions, as in "all(count, not(forbid:abc))". An argument runs
+// up to the next comma or closing parenthesis that is not nested in
+// parentheses, unless it is a double quoted Go string.
+type policyParser struct {
+	text string
+	pos  int
+}
+
+func (p *policyParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("Invalid policy %q at %d: %s", p.text, p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *policyParser) skipSpace() {
+	for p.pos < len(p.text) && p.text[p.pos] == ' ' {
+		p.pos += 1
+	}
+}
+
+func (p *policyParser) name() string {
+	start := p.pos
+	for p.pos < len(p.text) && strings.IndexByte("abcdefghijklmnopqrstuvwxyz0123456789-_", p.text[p.pos]) >= 0 {
+		p.pos += 1
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *policyParser) arg() (string, error) {
+	if p.pos < len(p.text) && p.text[p.pos] == '"' {
+		start := p.pos
+		for p.pos += 1; p.pos < len(p.text) && p.text[p.pos] != '"'; p.pos += 1 {
+			if p.text[p.pos] == '\\' {
+				p.pos += 1
+			}
+		}
+
+		if p.pos >= len(p.text) {
+			p.pos = start
+			return "", p.errorf("unterminated string")
+		}
+
+		p.pos += 1
+		arg, err := strconv.Unquote(p.text[start:p.pos])
+		if err != nil {
+			p.pos = start
+			return "", p.errorf("invalid string: %s", err)
+		}
+		return arg, nil
+	}
+
+	start := p.pos
+	depth := 0
+	for ; p.pos < len(p.text); p.pos += 1 {
+		switch p.text[p.pos] {
+		case '(':
+			depth += 1
+		case ')':
+			if depth == 0 {
+				return strings.TrimSpace(p.text[start:p.pos]), nil
+			}
+			depth -= 1
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(p.text[start:p.pos]), nil
+			}
+		}
+	}
+	return strings.TrimSpace(p.text[start:]), nil
+}
+
+func (p *policyParser) rule() (Rule, error) {
+	p.skipSpace()
+	start := p.pos
+	name := p.name()
+	factory, ok := policies[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown policy %q, expected one of %s", name, strings.Join(Policies(), ", "))
+	}
+
+	if factory.Combine != nil {
+		return p.combination(name, factory)
+	}
+
+	arg := ""
+	if p.pos < len(p.text) && p.text[p.pos] == ':' {
+		p.pos += 1
+		var err error
+		if arg, err = p.arg(); err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := factory.Arg(arg)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%s: %s", name, err)
+	}
+	return rule, nil
+}
+
+func (p *policyParser) combination(name string, factory RuleFactory) (Rule, error) {
+	start := p.pos
+	if p.pos >= len(p.text) || p.text[p.pos] != '(' {
+		return nil, p.errorf("%s expects a list of policies in parentheses", name)
+	}
+	p.pos += 1
+
+	rules := make([]Rule, 0)
+	for {
+		rule, err := p.rule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+
+		p.skipSpace()
+		if p.pos >= len(p.text) {
+			return nil, p.errorf("missing )")
+		}
+
+		p.pos += 1
+		if p.text[p.pos-1] == ')' {
+			break
+		}
+		if p.text[p.pos-1] != ',' {
+			p.pos -= 1
+			return nil, p.errorf("expected , or )")
+		}
+	}
+
+	rule, err := factory.Combine(rules)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%s: %s", name, err)
+	}
+	return rule, nil
+}
+
+// ParsePolicy builds the rule described by a policy expression, see
+// policyParser for the syntax.
+func ParsePolicy(text string) (Rule, error) {
+	p := &policyParser{text: text}
+	rule, err := p.rule()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return nil, p.errorf("unexpected %q", p.text[p.pos:])
+	}
+	return rule, nil
+}
@@ -0,0 +1,225 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type EditOp string
+
+const (
+	Insert     EditOp = "insert"
+	Delete     EditOp = "delete"
+	Substitute EditOp = "substitute"
+)
+
+// Edit changes the password at the 1-based character Position of the
+// original password. Insertions go before that position, which is one past
+// the last character for insertions at the end.
+type Edit struct {
+	Op       EditOp `json:"op"`
+	Position int    `json:"position"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+}
+
+func (edit Edit) String() string {
+	switch edit.Op {
+	case Insert:
+		return fmt.Sprintf("insert %q at %d", edit.To, edit.Position)
+	case Delete:
+		return fmt.Sprintf("delete %q at %d", edit.From, edit.Position)
+	default:
+		return fmt.Sprintf("replace %q at %d with %q", edit.From, edit.Position, edit.To)
+	}
+}
+
+type Repair struct {
+	Password string `json:"password"`
+	Edits    []Edit `json:"edits"`
+}
+
+// repairState is a point in the search over edit sequences: consumed
+// characters of the original password, the length of the repaired password
+// and the number of policy characters in it, both capped past the highest
+// relevant value, and whether each policy position holds the character.
+type repairState struct {
+	consumed int
+	length   int
+	count    int
+	first    bool
+	second   bool
+}
+
+type repairStep struct {
+	from repairState
+	edit *Edit
+	char string
+}
+
+// filler returns a character to use where any character but char will do.
+func filler(char string) string {
+	for _, candidate := range []string{"x", "y"} {
+		if candidate != char {
+			return candidate
+		}
+	}
+	return "x"
+}
+
+// repairQueue is the deque of a 0-1 breadth first search. States reached
+// without an edit are pushed on front, which is taken from before back.
+type repairQueue struct {
+	front []repairState
+	back  []repairState
+}
+
+func (queue *repairQueue) push(state repairState, free bool) {
+	if free {
+		queue.front = append(queue.front, state)
+	} else {
+		queue.back = append(queue.back, state)
+	}
+}
+
+func (queue *repairQueue) pop() (repairState, bool) {
+	if n := len(queue.front); n > 0 {
+		state := queue.front[n-1]
+		queue.front = queue.front[:n-1]
+		return state, true
+	}
+
+	if len(queue.back) > 0 {
+		state := queue.back[0]
+		queue.back = queue.back[1:]
+		return state, true
+	}
+	return repairState{}, false
+}
+
+// RepairPassword returns the fewest insertions, deletions and substitutions
+// of characters that make the password of entry satisfy both the count and
+// the positions policy, along with the repaired password. Characters are
+// counted in units.
+func RepairPassword(entry PasswordEntry, units Units) (Repair, error) {
+	policy := entry.Policy
+	if policy.MinCount < 1 || policy.MinCount > policy.MaxCount {
+		return Repair{}, fmt.Errorf("Invalid policy %s", policy)
+	}
+	if policy.MinCount == policy.MaxCount {
+		return Repair{}, errors.New("No password satisfies both policies when both positions are the same")
+	}
+
+	char := units.Character(policy)
+	other := filler(char)
+	source := units.Split(entry.Password)
+	limit := policy.MaxCount + 1
+
+	capped := func(value int) int {
+		if value > limit {
+			return limit
+		}
+		return value
+	}
+
+	next := func(state repairState, emitted string) repairState {
+		position := state.length + 1
+		state.length = capped(position)
+		if emitted == char {
+			state.count = capped(state.count + 1)
+			state.first = state.first || position == policy.MinCount
+			state.second = state.second || position == policy.MaxCount
+		}
+		return state
+	}
+
+	// A breadth first search over edits, where keeping a character costs
+	// nothing and is explored before any edit.
+	start := repairState{}
+	steps := map[repairState]repairStep{start: {}}
+	cost := map[repairState]int{start: 0}
+	queue := &repairQueue{}
+	queue.push(start, true)
+
+	visit := func(state repairState, step repairStep, stepCost int, front bool) {
+		total := cost[step.from] + stepCost
+		if known, ok := cost[state]; ok && known <= total {
+			return
+		}
+
+		cost[state] = total
+		steps[state] = step
+		queue.push(state, front)
+	}
+
+	var goal *repairState
+	done := make(map[repairState]bool)
+	for {
+		state, ok := queue.pop()
+		if !ok {
+			break
+		}
+		if done[state] {
+			continue
+		}
+		done[state] = true
+
+		if state.consumed == len(source) && state.count >= policy.MinCount &&
+			state.count <= policy.MaxCount && state.first != state.second {
+			goal = &state
+			break
+		}
+
+		position := state.consumed + 1
+		for _, emitted := range []string{char, other} {
+			if state.consumed < len(source) {
+				kept := next(state, emitted)
+				kept.consumed += 1
+				if source[state.consumed] == emitted || (emitted == other && source[state.consumed] != char) {
+					visit(kept, repairStep{from: state, char: source[state.consumed]}, 0, true)
+				} else {
+					edit := &Edit{Op: Substitute, Position: position, From: source[state.consumed], To: emitted}
+					visit(kept, repairStep{from: state, edit: edit, char: emitted}, 1, false)
+				}
+			}
+
+			if inserted := next(state, emitted); inserted != state {
+				edit := &Edit{Op: Insert, Position: position, To: emitted}
+				visit(inserted, repairStep{from: state, edit: edit, char: emitted}, 1, false)
+			}
+		}
+
+		if state.consumed < len(source) {
+			deleted := state
+			deleted.consumed += 1
+			edit := &Edit{Op: Delete, Position: position, From: source[state.consumed]}
+			visit(deleted, repairStep{from: state, edit: edit}, 1, false)
+		}
+	}
+
+	if goal == nil {
+		return Repair{}, fmt.Errorf("No repair found for %s: %s", policy, entry.Password)
+	}
+
+	chars := make([]string, 0, len(source))
+	edits := make([]Edit, 0)
+	for state := *goal; state != start; state = steps[state].from {
+		step := steps[state]
+		if step.edit != nil {
+			edits = append(edits, *step.edit)
+		}
+		if step.char != "" {
+			chars = append(chars, step.char)
+		}
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+
+	return Repair{Password: strings.Join(chars, ""), Edits: edits}, nil
+}
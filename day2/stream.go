@@ -0,0 +1,193 @@
+package day2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+const DefaultBatchSize = 1024
+
+type StreamOptions struct {
+	// Workers is the number of goroutines validating entries, GOMAXPROCS
+	// when zero.
+	Workers   int
+	BatchSize int
+}
+
+// StreamResult is the verdict for the Index-th entry of a stream, which was
+// read from Line.
+type StreamResult struct {
+	Index   int
+	Line    int
+	Entry   PasswordEntry
+	Verdict Verdict
+}
+
+type streamLine struct {
+	number int
+	text   string
+}
+
+type streamBatch struct {
+	sequence int
+	first    int
+	lines    []streamLine
+	results  []StreamResult
+	err      error
+}
+
+func validateBatch(batch *streamBatch, name string, rule Rule) {
+	batch.results = make([]StreamResult, 0, len(batch.lines))
+	for ix, line := range batch.lines {
+		entry, err := ReadEntry(line.text)
+		if err != nil {
+			var parseError *input.ParseError
+			if !errors.As(err, &parseError) {
+				parseError = &input.ParseError{Err: err}
+			}
+
+			copied := *parseError
+			copied.File, copied.Line, copied.Text = name, line.number, line.text
+			batch.err = &copied
+			return
+		}
+
+		batch.results = append(batch.results, StreamResult{
+			Index:   batch.first + ix,
+			Line:    line.number,
+			Entry:   entry,
+			Verdict: rule(entry),
+		})
+	}
+}
+
+// ValidateStream reads entries from r and validates them with rule on a pool
+// of workers, calling emit for every entry in the order of the input. It
+// stops at the first malformed entry, after emitting the entries before it,
+// when emit returns an error or when ctx is done. Only a few batches per
+// worker are held in memory at any time.
+func ValidateStream(ctx context.Context, r io.Reader, rule Rule, options StreamOptions, emit func(StreamResult) error) error {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	scanner := input.NewScanner(r)
+	inFlight := make(chan struct{}, 2*workers)
+	batches := make(chan *streamBatch)
+	validated := make(chan *streamBatch)
+	readErr := make(chan error, 1)
+
+	go func() {
+		defer close(batches)
+
+		sequence, index := 0, 1
+		batch := &streamBatch{first: index}
+		send := func() bool {
+			select {
+			case inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+
+			select {
+			case batches <- batch:
+			case <-ctx.Done():
+				return false
+			}
+
+			sequence += 1
+			batch = &streamBatch{sequence: sequence, first: index}
+			return true
+		}
+
+		for scanner.Scan() {
+			batch.lines = append(batch.lines, streamLine{number: scanner.Line(), text: scanner.Text()})
+			index += 1
+			if len(batch.lines) == batchSize && !send() {
+				return
+			}
+		}
+
+		if len(batch.lines) > 0 {
+			send()
+		}
+		readErr <- scanner.Err()
+	}()
+
+	done := make(chan struct{})
+	for worker := 0; worker < workers; worker += 1 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for batch := range batches {
+				validateBatch(batch, scanner.Name(), rule)
+				select {
+				case validated <- batch:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for worker := 0; worker < workers; worker += 1 {
+			<-done
+		}
+		close(validated)
+	}()
+
+	pending := make(map[int]*streamBatch)
+	next := 0
+	for batch := range validated {
+		pending[batch.sequence] = batch
+		for ready, ok := pending[next]; ok; ready, ok = pending[next] {
+			delete(pending, next)
+			next += 1
+			<-inFlight
+
+			for _, result := range ready.results {
+				if err := emit(result); err != nil {
+					return err
+				}
+			}
+			if ready.err != nil {
+				return ready.err
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return <-readErr
+}
+
+// CountValidStream counts the entries read from r that satisfy rule, see
+// ValidateStream.
+func CountValidStream(ctx context.Context, r io.Reader, rule Rule, options StreamOptions) (int, error) {
+	count := 0
+	err := ValidateStream(ctx, r, rule, options, func(result StreamResult) error {
+		if result.Verdict.Valid {
+			count += 1
+		}
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,141 @@
+package day2
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Reason string
+
+const (
+	TooFew             Reason = "too few occurrences"
+	TooMany            Reason = "too many occurrences"
+	NoPositionMatches  Reason = "no position matches"
+	BothPositionsMatch Reason = "both positions match"
+	NoMatch            Reason = "does not match pattern"
+	TooShort           Reason = "too short"
+	MissingClass       Reason = "missing character class"
+	Forbidden          Reason = "contains forbidden substring"
+	NoAlternative      Reason = "satisfies none of the alternatives"
+	Negated            Reason = "satisfies negated policy"
+)
+
+// Verdict explains why a password does or does not satisfy a policy. Count
+// and Positions hold what was observed, Min and Max the expected range and
+// Detail the character, pattern, class or substring the policy is about.
+// Combinators keep the verdicts they are based on in Causes.
+type Verdict struct {
+	Valid     bool      `json:"valid"`
+	Policy    string    `json:"policy"`
+	Reason    Reason    `json:"reason,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	Count     int       `json:"count"`
+	Positions []int     `json:"positions,omitempty"`
+	Min       int       `json:"min,omitempty"`
+	Max       int       `json:"max,omitempty"`
+	Causes    []Verdict `json:"causes,omitempty"`
+}
+
+func (verdict Verdict) String() string {
+	if verdict.Valid {
+		return "valid"
+	}
+
+	switch verdict.Reason {
+	case TooFew, TooMany:
+		return fmt.Sprintf("%s of %q: %d, expected %d-%d",
+			verdict.Reason, verdict.Detail, verdict.Count, verdict.Min, verdict.Max)
+	case NoPositionMatches, BothPositionsMatch:
+		return fmt.Sprintf("%s of %d and %d for %q, matching %v",
+			verdict.Reason, verdict.Min, verdict.Max, verdict.Detail, verdict.Positions)
+	case TooShort:
+		return fmt.Sprintf("%s: %d characters, expected at least %d", verdict.Reason, verdict.Count, verdict.Min)
+	case NoAlternative, Negated:
+		causes := make([]string, len(verdict.Causes))
+		for ix, cause := range verdict.Causes {
+			causes[ix] = cause.Policy
+		}
+		return fmt.Sprintf("%s: %s", verdict.Reason, strings.Join(causes, ", "))
+	case Forbidden:
+		return fmt.Sprintf("%s %q at %v", verdict.Reason, verdict.Detail, verdict.Positions)
+	default:
+		return fmt.Sprintf("%s %q", verdict.Reason, verdict.Detail)
+	}
+}
+
+func checkCount(entry PasswordEntry, units Units) Verdict {
+	verdict := Verdict{
+		Valid:  true,
+		Policy: "count",
+		Detail: units.Character(entry.Policy),
+		Min:    entry.Policy.MinCount,
+		Max:    entry.Policy.MaxCount,
+	}
+
+	for _, character := range units.Split(entry.Password) {
+		if character == verdict.Detail {
+			verdict.Count += 1
+		}
+	}
+
+	if verdict.Count < verdict.Min {
+		verdict.Valid = false
+		verdict.Reason = TooFew
+	} else if verdict.Count > verdict.Max {
+		verdict.Valid = false
+		verdict.Reason = TooMany
+	}
+	return verdict
+}
+
+func checkPositions(entry PasswordEntry, units Units) Verdict {
+	verdict := Verdict{
+		Policy:    "positions",
+		Detail:    units.Character(entry.Policy),
+		Positions: make([]int, 0, 2),
+		Min:       entry.Policy.MinCount,
+		Max:       entry.Policy.MaxCount,
+	}
+
+	characters := units.Split(entry.Password)
+	for _, position := range []int{entry.Policy.MinCount, entry.Policy.MaxCount} {
+		if position >= 1 && position <= len(characters) && characters[position-1] == verdict.Detail {
+			verdict.Positions = append(verdict.Positions, position)
+		}
+	}
+
+	verdict.Count = len(verdict.Positions)
+	switch verdict.Count {
+	case 0:
+		verdict.Reason = NoPositionMatches
+	case 1:
+		verdict.Valid = true
+	default:
+		verdict.Reason = BothPositionsMatch
+	}
+	return verdict
+}
+
+// CheckCount is the policy of part 1: the character must occur between
+// MinCount and MaxCount times.
+func CheckCount(entry PasswordEntry) Verdict {
+	return checkCount(entry, Runes)
+}
+
+// CheckPositions is the policy of part 2: the character must be at exactly
+// one of the 1-based character positions MinCount and MaxCount.
+func CheckPositions(entry PasswordEntry) Verdict {
+	return checkPositions(entry, Runes)
+}
+
+// CheckCountGraphemes is CheckCount counting grapheme clusters, so that a
+// character followed by a combining mark does not count as that character.
+func CheckCountGraphemes(entry PasswordEntry) Verdict {
+	return checkCount(entry, Graphemes)
+}
+
+// CheckPositionsGraphemes is CheckPositions with positions counted in
+// grapheme clusters.
+func CheckPositionsGraphemes(entry PasswordEntry) Verdict {
+	return checkPositions(entry, Graphemes)
+}
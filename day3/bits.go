@@ -0,0 +1,215 @@
+package day3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+// MaxRowWidth is the widest row the streaming readers accept.
+const MaxRowWidth = 1 << 28
+
+// BitRow is a row of the field with one bit per cell, set for trees.
+type BitRow struct {
+	Width int
+	Words []uint64
+}
+
+func (row BitRow) HasTree(x int) bool {
+	x = mod(x, row.Width)
+	return row.Words[x/64]&(1<<uint(x%64)) != 0
+}
+
+// parseBitRow packs line into words, which must hold enough words for the
+// width of the line.
+func parseBitRow(line string, words []uint64) error {
+	for ix := range words {
+		words[ix] = 0
+	}
+
+	for x := 0; x < len(line); x += 1 {
+		switch line[x] {
+		case '#':
+			words[x/64] |= 1 << uint(x%64)
+		case '.':
+		default:
+			return input.Errorf(x+1, "Invalid character %q, expected one of \".#\"", line[x])
+		}
+	}
+	return nil
+}
+
+// scanBitRows calls row for every row read from r. The words of a row are
+// reused for the next one.
+func scanBitRows(r io.Reader, row func(BitRow) error) error {
+	scanner := input.NewScanner(r)
+	scanner.Buffer(MaxRowWidth + 2)
+
+	var current BitRow
+	for scanner.Scan() {
+		line := scanner.Text()
+		if current.Words == nil {
+			current = BitRow{Width: len(line), Words: make([]uint64, (len(line)+63)/64)}
+		}
+
+		if len(line) != current.Width {
+			column := current.Width + 1
+			if len(line) < current.Width {
+				column = len(line) + 1
+			}
+			return scanner.Wrap(input.Errorf(column, "All lines should have width %d", current.Width))
+		}
+
+		if err := parseBitRow(line, current.Words); err != nil {
+			return scanner.Wrap(err)
+		}
+
+		if err := row(current); err != nil {
+			return scanner.Wrap(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if current.Words == nil {
+		return errors.New("Input file should not be empty")
+	}
+	return nil
+}
+
+// BitField is a Field packed into 64 cells per word, each row starting at a
+// new word.
+type BitField struct {
+	width  int
+	height int
+	stride int
+	words  []uint64
+}
+
+func ReadBitField(r io.Reader) (*BitField, error) {
+	field := &BitField{}
+	err := scanBitRows(r, func(row BitRow) error {
+		field.width = row.Width
+		field.stride = len(row.Words)
+		field.height += 1
+		field.words = append(field.words, row.Words...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func NewBitField(field Field) *BitField {
+	width := len(field.Template[0])
+	packed := &BitField{
+		width:  width,
+		height: len(field.Template),
+		stride: (width + 63) / 64,
+	}
+
+	packed.words = make([]uint64, packed.stride*packed.height)
+	for y, row := range field.Template {
+		for x, tree := range row {
+			if tree {
+				packed.words[y*packed.stride+x/64] |= 1 << uint(x%64)
+			}
+		}
+	}
+	return packed
+}
+
+func (field *BitField) Width() int {
+	return field.width
+}
+
+func (field *BitField) Height() int {
+	return field.height
+}
+
+func (field *BitField) Row(y int) BitRow {
+	return BitRow{Width: field.width, Words: field.words[y*field.stride : (y+1)*field.stride]}
+}
+
+// HasTree reports whether there is a tree at x, y, where x wraps around the
+// width of the field in both directions, like Field.HasTree.
+func (field *BitField) HasTree(x, y int) bool {
+	return field.Row(y).HasTree(x)
+}
+
+// Trees counts the trees on the path of slope, see Field.Path.
+func (field *BitField) Trees(slope Slope, torus bool) (int, error) {
+	x, y, steps, err := walk(field.width, field.height, slope, torus)
+	if err != nil {
+		return 0, err
+	}
+
+	trees := 0
+	for step := 0; step < steps; step += 1 {
+		if field.HasTree(x, mod(y, field.height)) {
+			trees += 1
+		}
+		x += slope.Dx
+		y += slope.Dy
+	}
+	return trees, nil
+}
+
+// SlopeCounter counts the trees on the paths of slopes going down from the
+// top left while the rows of the field are fed to it in order, so that only
+// a single row needs to be in memory.
+type SlopeCounter struct {
+	slopes []Slope
+	trees  []int
+	y      int
+}
+
+func NewSlopeCounter(slopes []Slope) (*SlopeCounter, error) {
+	for _, slope := range slopes {
+		if slope.Dy <= 0 {
+			return nil, fmt.Errorf("Slope %s: only slopes going down can be followed row by row", slope)
+		}
+	}
+
+	return &SlopeCounter{
+		slopes: slopes,
+		trees:  make([]int, len(slopes)),
+	}, nil
+}
+
+func (counter *SlopeCounter) Add(row BitRow) {
+	for ix, slope := range counter.slopes {
+		if counter.y%slope.Dy == 0 && row.HasTree(counter.y/slope.Dy*slope.Dx) {
+			counter.trees[ix] += 1
+		}
+	}
+	counter.y += 1
+}
+
+// Trees returns the trees counted so far for every slope.
+func (counter *SlopeCounter) Trees() []int {
+	return counter.trees
+}
+
+// CountTreesStream counts the trees for every slope while reading the field
+// from r one row at a time.
+func CountTreesStream(r io.Reader, slopes []Slope) ([]int, error) {
+	counter, err := NewSlopeCounter(slopes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanBitRows(r, func(row BitRow) error {
+		counter.Add(row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counter.Trees(), nil
+}
@@ -0,0 +1,108 @@
+package day3
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func randomField(random *rand.Rand, width, height int) string {
+	var text strings.Builder
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			if random.Intn(3) == 0 {
+				text.WriteByte('#')
+			} else {
+				text.WriteByte('.')
+			}
+		}
+		text.WriteByte('\n')
+	}
+	return text.String()
+}
+
+func TestBitField(t *testing.T) {
+	random := rand.New(rand.NewSource(23))
+	for _, width := range []int{1, 5, 63, 64, 65, 127, 128, 130, 200} {
+		text := randomField(random, width, 1+random.Intn(12))
+
+		field, err := ReadField(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		read, err := ReadBitField(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		packed := NewBitField(field)
+
+		height := len(field.Template)
+		for _, bits := range []*BitField{read, packed} {
+			if bits.Width() != width || bits.Height() != height {
+				t.Fatalf("expected %dx%d, got %dx%d", width, height, bits.Width(), bits.Height())
+			}
+
+			for y := 0; y < height; y += 1 {
+				for x := -2 * width; x < 2*width; x += 1 {
+					if bits.HasTree(x, y) != field.HasTree(x, y) {
+						t.Fatalf("width %d: cell %d, %d differs", width, x, y)
+					}
+				}
+			}
+		}
+
+		slopes := make([]Slope, 0)
+		for _, dy := range []int{-2, -1, 0, 1, 2, 3} {
+			for _, dx := range []int{-width - 1, -65, -7, -3, -1, 0, 1, 3, 7, 64, width + 1} {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				slope := Slope{Dx: dx, Dy: dy}
+
+				for _, torus := range []bool{false, true} {
+					expected, _ := field.Trees(slope, torus)
+					if trees, err := read.Trees(slope, torus); err != nil || trees != expected {
+						t.Errorf("width %d, %s (torus %v): expected %d trees, got %d (%v)", width, slope, torus, expected, trees, err)
+					}
+				}
+
+				if dy > 0 {
+					slopes = append(slopes, slope)
+				}
+			}
+		}
+
+		trees, err := CountTreesStream(strings.NewReader(text), slopes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for ix, slope := range slopes {
+			if expected, _ := field.Trees(slope, false); trees[ix] != expected {
+				t.Errorf("width %d, %s: expected %d trees streamed, got %d", width, slope, expected, trees[ix])
+			}
+		}
+	}
+}
+
+func TestReadBitFieldErrors(t *testing.T) {
+	cases := []struct {
+		text    string
+		message string
+	}{
+		{"", "should not be empty"},
+		{"..#\n.x.\n", "2:2"},
+		{"..#\n....\n", "2:4"},
+		{"..#\n..\n", "2:3"},
+	}
+
+	for _, c := range cases {
+		if _, err := ReadBitField(strings.NewReader(c.text)); err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%q: expected an error with %q, got %v", c.text, c.message, err)
+		}
+	}
+
+	if _, err := CountTreesStream(strings.NewReader("..#\n"), []Slope{{Dx: 1, Dy: 0}}); err == nil {
+		t.Errorf("expected an error streaming a horizontal slope")
+	}
+}
@@ -0,0 +1,186 @@
+package day3
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type Field struct {
+	Template [][]bool
+}
+
+func readFieldRow(row []byte) []bool {
+	template := make([]bool, len(row))
+	for columnIndex, c := range row {
+		template[columnIndex] = c == '#'
+	}
+
+	return template
+}
+
+func ReadField(r io.Reader) (Field, error) {
+	var field Field
+
+	grid, err := input.Grid(r, ".#")
+	if err != nil {
+		return field, err
+	}
+
+	if len(grid) == 0 {
+		return field, errors.New("Input file should not be empty")
+	}
+
+	field.Template = make([][]bool, len(grid))
+	for rowIndex, row := range grid {
+		field.Template[rowIndex] = readFieldRow(row)
+	}
+
+	return field, nil
+}
+
+// HasTree reports whether there is a tree at x, y, where x wraps around the
+// width of the field in both directions.
+func (field *Field) HasTree(x, y int) bool {
+	return field.Template[y][mod(x, len(field.Template[0]))]
+}
+
+// CountTrees counts the trees from the top left down to the bottom of the
+// field, or 0 for a slope that does not move.
+func CountTrees(field Field, dx, dy int) int {
+	trees, _ := field.Trees(Slope{Dx: dx, Dy: dy}, false)
+	return trees
+}
+
+// Slopes is a list of <dx>,<dy> steps separated by spaces, such as "3,1 1,2".
+type Slopes []Slope
+
+func (slopes *Slopes) String() string {
+	parts := make([]string, 0, len(*slopes))
+	for _, slope := range *slopes {
+		parts = append(parts, slope.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func (slopes *Slopes) Set(value string) error {
+	parsed := make(Slopes, 0)
+	for _, part := range strings.Fields(value) {
+		dx, dy, ok := input.ParsePair(part)
+		if !ok {
+			return fmt.Errorf("slope should be formatted as <dx>,<dy>: %s", part)
+		}
+
+		if dx == 0 && dy == 0 {
+			return fmt.Errorf("slope should move in at least one direction: %s", part)
+		}
+
+		parsed = append(parsed, Slope{Dx: dx, Dy: dy})
+	}
+
+	*slopes = parsed
+	return nil
+}
+
+func MultiplyTrees(field Field, slopes []Slope) int {
+	total := 1
+	for _, slope := range slopes {
+		total *= CountTrees(field, slope.Dx, slope.Dy)
+	}
+	return total
+}
+
+type Solver struct {
+	field    Field
+	slope    Slopes
+	slopes   Slopes
+	torus    bool
+	stream   bool
+	streamed Ranking
+	details  map[int]solver.Details
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	s.slope = Slopes{{3, 1}}
+	s.slopes = Slopes{{1, 1}, {3, 1}, {5, 1}, {7, 1}, {1, 2}}
+
+	params.Var(&s.slope, "slope", "slope to count the trees of in part 1")
+	params.Var(&s.slopes, "slopes", "slopes to multiply the tree counts of in part 2")
+	params.BoolVar(&s.torus, "torus", false, "wrap the field vertically too and follow each slope until it is back at the start")
+	params.BoolVar(&s.stream, "stream", false, "count the trees while reading the field row by row instead of loading it")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	if !s.stream {
+		field, err := ReadField(r)
+		s.field = field
+		return err
+	}
+
+	if s.torus {
+		return errors.New("A torus cannot be followed while streaming the field")
+	}
+
+	slopes := append(append([]Slope{}, s.slope...), s.slopes...)
+	trees, err := CountTreesStream(r, slopes)
+	if err != nil {
+		return err
+	}
+
+	s.streamed = make(Ranking, len(slopes))
+	for ix, slope := range slopes {
+		s.streamed[ix] = SlopeResult{Slope: slope, Trees: trees[ix]}
+	}
+	return nil
+}
+
+// evaluate counts the trees for slopes, which were counted while parsing
+// when streaming starting at offset in the streamed results.
+func (s *Solver) evaluate(slopes []Slope, offset int) (Ranking, error) {
+	if s.stream {
+		return s.streamed[offset : offset+len(slopes)], nil
+	}
+	return EvaluateSlopes(s.field, slopes, s.torus)
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	if len(s.slope) != 1 {
+		return 0, errors.New("Part 1 needs exactly one slope")
+	}
+
+	ranking, err := s.evaluate(s.slope, 0)
+	if err != nil {
+		return 0, err
+	}
+	return solver.Answer(ranking[0].Trees), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	ranking, err := s.evaluate(s.slopes, len(s.slope))
+	if err != nil {
+		return 0, err
+	}
+
+	trees := make([]string, len(ranking))
+	for ix, result := range ranking {
+		trees[ix] = fmt.Sprintf("%s=%d", result.Slope, result.Trees)
+	}
+
+	s.details[2] = solver.Details{"trees": trees}
+	return solver.Answer(ranking.Product()), nil
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
+
+func init() {
+	solver.Register(3, func() solver.Solver {
+		return &Solver{details: make(map[int]solver.Details)}
+	})
+}
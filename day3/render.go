@@ -0,0 +1,208 @@
+package day3
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+const (
+	// MaxCanvasCells bounds the number of cells of a canvas, which takes a
+	// byte per cell.
+	MaxCanvasCells = 1 << 24
+	// MaxCanvasSlopes is the number of slopes a canvas can tell apart.
+	MaxCanvasSlopes = math.MaxUint8
+)
+
+// Canvas is the field repeated to the right and left as far as the paths of
+// a set of slopes go, with the cells each path visits. Visits holds, for
+// every cell, one more than the index of the first slope visiting it, or 0.
+type Canvas struct {
+	field   *Field
+	Slopes  []Slope
+	OffsetX int
+	Width   int
+	Height  int
+	Visits  [][]uint8
+}
+
+func floorDiv(a, b int) int {
+	return (a - mod(a, b)) / b
+}
+
+func (field *Field) blankCanvas(offsetX, width int) *Canvas {
+	canvas := &Canvas{
+		field:   field,
+		OffsetX: offsetX,
+		Width:   width,
+		Height:  len(field.Template),
+		Visits:  make([][]uint8, len(field.Template)),
+	}
+
+	for y := range canvas.Visits {
+		canvas.Visits[y] = make([]uint8, width)
+	}
+	return canvas
+}
+
+// RouteCanvas lays out a route over a single copy of the field.
+func (field *Field) RouteCanvas(route Route) *Canvas {
+	canvas := field.blankCanvas(0, len(field.Template[0]))
+	for _, cell := range route.Path {
+		canvas.Visits[cell[1]][cell[0]] = 1
+	}
+	return canvas
+}
+
+// Canvas lays out the paths of slopes over the field. On a torus every path
+// stays within a single copy of the field.
+func (field *Field) Canvas(slopes []Slope, torus bool) (*Canvas, error) {
+	width, height := len(field.Template[0]), len(field.Template)
+	if len(slopes) > MaxCanvasSlopes {
+		return nil, fmt.Errorf("At most %d slopes can be drawn, got %d", MaxCanvasSlopes, len(slopes))
+	}
+
+	minX, maxX := 0, 0
+	for _, slope := range slopes {
+		x, _, steps, err := field.walk(slope, torus)
+		if err != nil {
+			return nil, fmt.Errorf("Slope %s: %s", slope, err)
+		}
+
+		if !torus && steps > 1 && (slope.Dx > MaxCanvasCells || slope.Dx < -MaxCanvasCells) {
+			return nil, fmt.Errorf("Slope %s: the canvas would be wider than %d cells", slope, MaxCanvasCells)
+		}
+		if !torus && steps > 0 {
+			last := x + (steps-1)*slope.Dx
+			if last < minX {
+				minX = last
+			}
+			if last > maxX {
+				maxX = last
+			}
+		}
+	}
+
+	offsetX := floorDiv(minX, width) * width
+	canvasWidth := (floorDiv(maxX, width)+1)*width - offsetX
+	if canvasWidth > MaxCanvasCells/height {
+		return nil, fmt.Errorf("A canvas of %dx%d cells is larger than %d cells", canvasWidth, height, MaxCanvasCells)
+	}
+
+	canvas := field.blankCanvas(offsetX, canvasWidth)
+	canvas.Slopes = slopes
+
+	for ix, slope := range slopes {
+		x, y, steps, _ := field.walk(slope, torus)
+		for step := 0; step < steps; step += 1 {
+			column := x - canvas.OffsetX
+			if torus {
+				column = mod(x, width)
+			}
+
+			if visit := &canvas.Visits[mod(y, height)][column]; *visit == 0 {
+				*visit = uint8(ix + 1)
+			}
+			x += slope.Dx
+			y += slope.Dy
+		}
+	}
+
+	return canvas, nil
+}
+
+// Cell returns the character of the cell at column x and row y of the canvas:
+// O or X for an open cell or a tree on a path, . or # otherwise.
+func (canvas *Canvas) Cell(x, y int) byte {
+	tree := canvas.field.HasTree(x+canvas.OffsetX, y)
+	switch {
+	case canvas.Visits[y][x] > 0 && tree:
+		return 'X'
+	case canvas.Visits[y][x] > 0:
+		return 'O'
+	case tree:
+		return '#'
+	default:
+		return '.'
+	}
+}
+
+var ansiColours = []int{31, 32, 33, 34, 35, 36}
+
+// WriteText writes the canvas as in the puzzle statement. With colour set the
+// cells of every slope get their own ANSI colour, and a legend follows.
+func (canvas *Canvas) WriteText(w io.Writer, colour bool) error {
+	writer := bufio.NewWriter(w)
+	for y := 0; y < canvas.Height; y += 1 {
+		for x := 0; x < canvas.Width; x += 1 {
+			cell := canvas.Cell(x, y)
+			if colour && canvas.Visits[y][x] > 0 {
+				fmt.Fprintf(writer, "\x1b[1;%dm%c\x1b[0m", ansiColours[int(canvas.Visits[y][x]-1)%len(ansiColours)], cell)
+			} else {
+				writer.WriteByte(cell)
+			}
+		}
+		writer.WriteByte('\n')
+	}
+
+	if colour {
+		for ix, slope := range canvas.Slopes {
+			fmt.Fprintf(writer, "\x1b[1;%dmO\x1b[0m %s\n", ansiColours[ix%len(ansiColours)], slope)
+		}
+	}
+	return writer.Flush()
+}
+
+var (
+	openColour = color.RGBA{0xf4, 0xf1, 0xe8, 0xff}
+	treeColour = color.RGBA{0x2e, 0x6b, 0x30, 0xff}
+	hitColour  = color.RGBA{0x00, 0x00, 0x00, 0xff}
+
+	pathColours = []color.RGBA{
+		{0xe0, 0x3c, 0x31, 0xff},
+		{0x31, 0x7a, 0xe0, 0xff},
+		{0xe0, 0xa1, 0x31, 0xff},
+		{0x8e, 0x44, 0xad, 0xff},
+		{0x16, 0xa0, 0x85, 0xff},
+		{0xd3, 0x54, 0x00, 0xff},
+	}
+)
+
+// Image draws every cell of the canvas as a square of cellSize pixels. Cells
+// on a path take the colour of their slope and trees hit get a black centre.
+func (canvas *Canvas) Image(cellSize int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, canvas.Width*cellSize, canvas.Height*cellSize))
+	for y := 0; y < canvas.Height; y += 1 {
+		for x := 0; x < canvas.Width; x += 1 {
+			cell := canvas.Cell(x, y)
+
+			fill := openColour
+			switch cell {
+			case 'O', 'X':
+				fill = pathColours[int(canvas.Visits[y][x]-1)%len(pathColours)]
+			case '#':
+				fill = treeColour
+			}
+
+			inset := cellSize / 4
+			for py := 0; py < cellSize; py += 1 {
+				for px := 0; px < cellSize; px += 1 {
+					pixel := fill
+					if cell == 'X' && px >= inset && px < cellSize-inset && py >= inset && py < cellSize-inset {
+						pixel = hitColour
+					}
+					img.SetRGBA(x*cellSize+px, y*cellSize+py, pixel)
+				}
+			}
+		}
+	}
+	return img
+}
+
+func (canvas *Canvas) WritePNG(w io.Writer, cellSize int) error {
+	return png.Encode(w, canvas.Image(cellSize))
+}
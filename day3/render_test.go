@@ -0,0 +1,216 @@
+package day3
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// The example of the puzzle statement with the path of slope 3,1, repeated
+// three times to the right.
+const exampleCanvas = `O.##.........##.........##.......
+#..O#...#..#...#...#..#...#...#..
+.#....X..#..#....#..#..#....#..#.
+..#.#...#O#..#.#...#.#..#.#...#.#
+.#...##..#..X...##..#..#...##..#.
+..#.##.......#.X#.......#.##.....
+.#.#.#....#.#.#.#.O..#.#.#.#....#
+.#........#.#........X.#........#
+#.##...#...#.##...#...#.X#...#...
+#...##....##...##....##...#X....#
+.#..#...#.#.#..#...#.#.#..#...X.#
+`
+
+func canvasText(t *testing.T, canvas *Canvas, colour bool) string {
+	t.Helper()
+
+	var text bytes.Buffer
+	if err := canvas.WriteText(&text, colour); err != nil {
+		t.Fatal(err)
+	}
+	return text.String()
+}
+
+// checkVisits verifies that the cells a canvas marks as visited are the cells
+// on the path of slope, and that the trees it marks are the trees hit.
+func checkVisits(t *testing.T, field Field, canvas *Canvas, slope Slope, torus bool) {
+	t.Helper()
+
+	path, err := field.Path(slope, torus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onPath := make(map[[2]int]bool)
+	for _, cell := range path {
+		onPath[cell] = true
+	}
+
+	width := len(field.Template[0])
+	hits := 0
+	for y := 0; y < canvas.Height; y += 1 {
+		for x := 0; x < canvas.Width; x += 1 {
+			cell := canvas.Cell(x, y)
+			if cell == 'X' {
+				hits += 1
+			}
+
+			visited := cell == 'O' || cell == 'X'
+			if visited != onPath[[2]int{mod(x+canvas.OffsetX, width), y}] && (torus || visited) {
+				t.Errorf("%s: cell %d, %d is %c", slope, x, y, cell)
+			}
+		}
+	}
+
+	if trees, _ := field.Trees(slope, torus); hits != trees {
+		t.Errorf("%s (torus %v): expected %d trees hit, got %d", slope, torus, trees, hits)
+	}
+}
+
+func TestCanvasExample(t *testing.T) {
+	field := readExample(t)
+
+	canvas, err := field.Canvas([]Slope{{3, 1}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canvas.OffsetX != 0 || canvas.Width != 33 || canvas.Height != 11 {
+		t.Errorf("expected 33x11 from 0, got %dx%d from %d", canvas.Width, canvas.Height, canvas.OffsetX)
+	}
+	if text := canvasText(t, canvas, false); text != exampleCanvas {
+		t.Errorf("expected\n%s\ngot\n%s", exampleCanvas, text)
+	}
+
+	text := canvasText(t, canvas, true)
+	if !strings.Contains(text, "\x1b[1;31mX\x1b[0m") || !strings.HasSuffix(text, "\x1b[1;31mO\x1b[0m 3,1\n") {
+		t.Errorf("expected the path and legend in red, got\n%s", text)
+	}
+}
+
+func TestCanvasSlopes(t *testing.T) {
+	field := readExample(t)
+
+	cases := []struct {
+		slopes  []Slope
+		torus   bool
+		offsetX int
+		width   int
+	}{
+		{[]Slope{{-3, 1}}, false, -33, 44},
+		{[]Slope{{1, 1}, {-1, 2}, {3, -1}}, false, -11, 44},
+		{[]Slope{{0, 1}}, false, 0, 11},
+		{[]Slope{{1, 2}}, true, 0, 11},
+		{[]Slope{{-3, 1}, {2, -3}}, true, 0, 11},
+	}
+
+	for _, c := range cases {
+		canvas, err := field.Canvas(c.slopes, c.torus)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if canvas.OffsetX != c.offsetX || canvas.Width != c.width || canvas.Height != 11 {
+			t.Errorf("%v (torus %v): expected %dx11 from %d, got %dx%d from %d",
+				c.slopes, c.torus, c.width, c.offsetX, canvas.Width, canvas.Height, canvas.OffsetX)
+		}
+
+		for _, slope := range c.slopes {
+			single, err := field.Canvas([]Slope{slope}, c.torus)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkVisits(t, field, single, slope, c.torus)
+		}
+	}
+
+	// The path of 1,2 goes round the torus twice before it is back at the
+	// start, covering every other row.
+	canvas, _ := field.Canvas([]Slope{{1, 2}}, true)
+	if text := canvasText(t, canvas, false); !strings.HasPrefix(text, "O.##.......\n#...#.O.#..\n.X....#..#.\n") {
+		t.Errorf("unexpected torus\n%s", text)
+	}
+}
+
+func TestCanvasLimits(t *testing.T) {
+	field := readExample(t)
+
+	if _, err := field.Canvas([]Slope{{2000000, 1}}, false); err == nil {
+		t.Errorf("expected an error for a canvas of more than %d cells", MaxCanvasCells)
+	}
+	if _, err := field.Canvas([]Slope{{1 << 40, 1}}, false); err == nil {
+		t.Errorf("expected an error for a slope wider than %d cells", MaxCanvasCells)
+	}
+	if _, err := field.Canvas([]Slope{{1 << 40, 1}}, true); err != nil {
+		t.Errorf("expected any slope to fit on the torus, got %s", err)
+	}
+
+	slopes := make([]Slope, MaxCanvasSlopes+1)
+	for ix := range slopes {
+		slopes[ix] = Slope{ix, 1}
+	}
+	if _, err := field.Canvas(slopes[:MaxCanvasSlopes], true); err != nil {
+		t.Errorf("expected %d slopes to fit, got %s", MaxCanvasSlopes, err)
+	}
+	if _, err := field.Canvas(slopes, true); err == nil {
+		t.Errorf("expected an error for %d slopes", len(slopes))
+	}
+}
+
+func TestRouteCanvas(t *testing.T) {
+	field := readExample(t)
+
+	route, err := FindRoute(field, RouteOptions{Moves: MoveSets["down"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canvas := field.RouteCanvas(route)
+	for y, row := range strings.Split(strings.TrimSuffix(canvasText(t, canvas, false), "\n"), "\n") {
+		if row[7] != 'O' && row[7] != 'X' || strings.Count(row, "O")+strings.Count(row, "X") != 1 {
+			t.Errorf("row %d: expected only column 7 on the route, got %s", y, row)
+		}
+	}
+}
+
+func TestCanvasImage(t *testing.T) {
+	field := readExample(t)
+
+	canvas, err := field.Canvas([]Slope{{3, 1}, {1, 2}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := canvas.Image(4)
+	if bounds := img.Bounds(); bounds.Dx() != canvas.Width*4 || bounds.Dy() != canvas.Height*4 {
+		t.Fatalf("expected %dx%d pixels, got %v", canvas.Width*4, canvas.Height*4, bounds)
+	}
+
+	cases := []struct {
+		x, y   int
+		colour color.RGBA
+	}{
+		// The start is on both paths and takes the colour of the first.
+		{0, 0, pathColours[0]},
+		{1, 0, openColour},
+		{2, 0, treeColour},
+		{1, 2, pathColours[1]},
+		{6, 2, pathColours[0]},
+	}
+
+	for _, c := range cases {
+		for _, pixel := range [][2]int{{0, 0}, {3, 3}} {
+			if colour := img.At(c.x*4+pixel[0], c.y*4+pixel[1]); colour != c.colour {
+				t.Errorf("cell %d, %d: expected %v, got %v", c.x, c.y, c.colour, colour)
+			}
+		}
+	}
+
+	// A tree that is hit has a black centre.
+	if colour := img.At(6*4+2, 2*4+2); colour != hitColour {
+		t.Errorf("expected a black centre in a tree hit, got %v", colour)
+	}
+
+	var png bytes.Buffer
+	if err := canvas.WritePNG(&png, 1); err != nil || !bytes.HasPrefix(png.Bytes(), []byte("\x89PNG")) {
+		t.Errorf("expected a PNG image, got %d bytes (%v)", png.Len(), err)
+	}
+}
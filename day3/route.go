@@ -0,0 +1,242 @@
+package day3
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+)
+
+type Move struct {
+	Dx int
+	Dy int
+}
+
+// MoveSets are the named sets of moves a route may be made of. No move goes
+// up, so that every route makes progress towards the bottom row or stays on
+// its row.
+var MoveSets = map[string][]Move{
+	"down":     {{0, 1}},
+	"sideways": {{0, 1}, {-1, 0}, {1, 0}},
+	"knight":   {{-2, 1}, {-1, 2}, {1, 2}, {2, 1}},
+}
+
+func MoveSetNames() []string {
+	names := make([]string, 0, len(MoveSets))
+	for name := range MoveSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type Algorithm string
+
+const (
+	BFS      Algorithm = "bfs"
+	Dijkstra Algorithm = "dijkstra"
+	AStar    Algorithm = "astar"
+)
+
+var Algorithms = []Algorithm{BFS, Dijkstra, AStar}
+
+type RouteOptions struct {
+	Moves []Move
+	// TreeFree only allows routes through open cells. Otherwise a route
+	// passes through as few trees as possible.
+	TreeFree  bool
+	Algorithm Algorithm
+}
+
+// Route leads from the top row to the bottom row. Path holds the cells on
+// the way, with x wrapped into the field, and Steps the number of moves.
+type Route struct {
+	Trees int      `json:"trees"`
+	Steps int      `json:"steps"`
+	Path  [][2]int `json:"path"`
+}
+
+var ErrNoRoute = errors.New("No route leads from the top row to the bottom row")
+
+type routeNode struct {
+	cell     int
+	cost     int
+	priority int
+}
+
+type routeQueue []routeNode
+
+func (q routeQueue) Len() int            { return len(q) }
+func (q routeQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q routeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *routeQueue) Push(x interface{}) { *q = append(*q, x.(routeNode)) }
+
+func (q *routeQueue) Pop() interface{} {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+// FindRoute finds the route with the fewest trees from any cell of the top
+// row to any cell of the bottom row, and with the fewest steps among those.
+// BFS only minimises steps and so needs TreeFree. Dijkstra and A* weigh a
+// tree as more than any number of steps, and A* also estimates the steps
+// left from the rows left to descend.
+func FindRoute(field Field, options RouteOptions) (Route, error) {
+	if len(options.Moves) == 0 {
+		return Route{}, errors.New("A route needs at least one move")
+	}
+
+	maxDy := 0
+	for _, move := range options.Moves {
+		if move.Dy < 0 {
+			return Route{}, fmt.Errorf("Move %d,%d goes up", move.Dx, move.Dy)
+		}
+		if move.Dx == 0 && move.Dy == 0 {
+			return Route{}, errNoMovement
+		}
+		if move.Dy > maxDy {
+			maxDy = move.Dy
+		}
+	}
+	if maxDy == 0 {
+		return Route{}, errors.New("A route needs a move going down")
+	}
+
+	width, height := len(field.Template[0]), len(field.Template)
+	cells := width * height
+	treeCost := cells + 1
+
+	var estimate func(y int) int
+	switch options.Algorithm {
+	case BFS:
+		if !options.TreeFree {
+			return Route{}, errors.New("BFS only finds tree-free routes")
+		}
+		treeCost = 0
+		estimate = func(y int) int { return 0 }
+	case Dijkstra, "":
+		estimate = func(y int) int { return 0 }
+	case AStar:
+		estimate = func(y int) int { return (height - 1 - y + maxDy - 1) / maxDy }
+	default:
+		return Route{}, fmt.Errorf("Unknown algorithm %q", options.Algorithm)
+	}
+
+	blocked := func(x, y int) bool {
+		return options.TreeFree && field.HasTree(x, y)
+	}
+	enter := func(x, y int) int {
+		if field.HasTree(x, y) {
+			return treeCost
+		}
+		return 0
+	}
+
+	cost := make([]int, cells)
+	previous := make([]int, cells)
+	for cell := range cost {
+		cost[cell] = -1
+		previous[cell] = -1
+	}
+
+	// BFS visits cells in the order they are found, which is by steps as
+	// every move costs one step.
+	queue := make(routeQueue, 0)
+	push := func(node routeNode) {
+		if options.Algorithm == BFS {
+			queue = append(queue, node)
+		} else {
+			heap.Push(&queue, node)
+		}
+	}
+	pop := func() routeNode {
+		if options.Algorithm == BFS {
+			node := queue[0]
+			queue = queue[1:]
+			return node
+		}
+		return heap.Pop(&queue).(routeNode)
+	}
+
+	for x := 0; x < width; x += 1 {
+		if !blocked(x, 0) {
+			cost[x] = enter(x, 0)
+			push(routeNode{cell: x, cost: cost[x], priority: cost[x] + estimate(0)})
+		}
+	}
+
+	done := make([]bool, cells)
+	goal := -1
+	for len(queue) > 0 {
+		node := pop()
+		if done[node.cell] || node.cost != cost[node.cell] {
+			continue
+		}
+		done[node.cell] = true
+
+		x, y := node.cell%width, node.cell/width
+		if y == height-1 {
+			goal = node.cell
+			break
+		}
+
+		for _, move := range options.Moves {
+			nx, ny := mod(x+move.Dx, width), y+move.Dy
+			if ny >= height || blocked(nx, ny) {
+				continue
+			}
+
+			next := ny*width + nx
+			nextCost := node.cost + 1 + enter(nx, ny)
+			if done[next] || (cost[next] >= 0 && cost[next] <= nextCost) {
+				continue
+			}
+
+			cost[next] = nextCost
+			previous[next] = node.cell
+			push(routeNode{cell: next, cost: nextCost, priority: nextCost + estimate(ny)})
+		}
+	}
+
+	if goal < 0 {
+		return Route{}, ErrNoRoute
+	}
+
+	route := Route{Path: make([][2]int, 0)}
+	for cell := goal; cell >= 0; cell = previous[cell] {
+		route.Path = append(route.Path, [2]int{cell % width, cell / width})
+		if field.HasTree(cell%width, cell/width) {
+			route.Trees += 1
+		}
+	}
+
+	for i, j := 0, len(route.Path)-1; i < j; i, j = i+1, j-1 {
+		route.Path[i], route.Path[j] = route.Path[j], route.Path[i]
+	}
+	route.Steps = len(route.Path) - 1
+	return route, nil
+}
+
+// ParseMoves reads the name of a move set or a list of <dx>,<dy> moves
+// separated by spaces.
+func ParseMoves(text string) ([]Move, error) {
+	if moves, ok := MoveSets[text]; ok {
+		return moves, nil
+	}
+
+	moves := make([]Move, 0)
+	for _, part := range strings.Fields(text) {
+		dx, dy, ok := input.ParsePair(part)
+		if !ok {
+			return nil, fmt.Errorf("Moves should be one of %s or a list of <dx>,<dy>: %s",
+				strings.Join(MoveSetNames(), ", "), text)
+		}
+		moves = append(moves, Move{Dx: dx, Dy: dy})
+	}
+	return moves, nil
+}
@@ -0,0 +1,135 @@
+package day3
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// checkRoute verifies that route leads from the top row to the bottom row of
+// field using moves, and that its trees and steps match its path.
+func checkRoute(t *testing.T, field Field, moves []Move, route Route) {
+	t.Helper()
+
+	width, height := len(field.Template[0]), len(field.Template)
+	if len(route.Path) == 0 || route.Path[0][1] != 0 || route.Path[len(route.Path)-1][1] != height-1 {
+		t.Fatalf("route %v does not lead from the top row to the bottom row", route.Path)
+	}
+	if route.Steps != len(route.Path)-1 {
+		t.Errorf("expected %d steps, got %d", len(route.Path)-1, route.Steps)
+	}
+
+	trees := 0
+	for ix, cell := range route.Path {
+		if field.HasTree(cell[0], cell[1]) {
+			trees += 1
+		}
+		if ix == 0 {
+			continue
+		}
+
+		previous := route.Path[ix-1]
+		moved := false
+		for _, move := range moves {
+			if mod(previous[0]+move.Dx, width) == cell[0] && previous[1]+move.Dy == cell[1] {
+				moved = true
+			}
+		}
+		if !moved {
+			t.Errorf("no move leads from %v to %v", previous, cell)
+		}
+	}
+
+	if trees != route.Trees {
+		t.Errorf("expected %d trees, got %d", trees, route.Trees)
+	}
+}
+
+func TestFindRouteExample(t *testing.T) {
+	field := readExample(t)
+
+	// Column 7 holds a single tree, the fewest of any column.
+	route, err := FindRoute(field, RouteOptions{Moves: MoveSets["down"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := make([][2]int, 11)
+	for y := range expected {
+		expected[y] = [2]int{7, y}
+	}
+	if route.Trees != 1 || route.Steps != 10 || !reflect.DeepEqual(route.Path, expected) {
+		t.Errorf("expected column 7, got %+v", route)
+	}
+
+	if _, err := FindRoute(field, RouteOptions{Moves: MoveSets["down"], TreeFree: true}); err != ErrNoRoute {
+		t.Errorf("expected no tree-free route straight down, got %v", err)
+	}
+
+	// Column 7 is open down to row 7, after which column 6 is open to the
+	// bottom, which takes a single step sideways.
+	for _, algorithm := range Algorithms {
+		route, err := FindRoute(field, RouteOptions{Moves: MoveSets["sideways"], TreeFree: true, Algorithm: algorithm})
+		if err != nil {
+			t.Fatalf("%s: %s", algorithm, err)
+		}
+		if route.Trees != 0 || route.Steps != 11 {
+			t.Errorf("%s: expected 0 trees in 11 steps, got %d in %d", algorithm, route.Trees, route.Steps)
+		}
+		checkRoute(t, field, MoveSets["sideways"], route)
+	}
+}
+
+func TestFindRouteAlgorithms(t *testing.T) {
+	random := rand.New(rand.NewSource(24))
+	for round := 0; round < 40; round += 1 {
+		text := randomField(random, 1+random.Intn(12), 2+random.Intn(12))
+		field, err := ReadField(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range MoveSetNames() {
+			moves := MoveSets[name]
+			for _, treeFree := range []bool{false, true} {
+				var expected Route
+				var expectedErr error
+				first := true
+				for _, algorithm := range Algorithms {
+					if algorithm == BFS && !treeFree {
+						continue
+					}
+
+					route, err := FindRoute(field, RouteOptions{Moves: moves, TreeFree: treeFree, Algorithm: algorithm})
+					if err == nil {
+						checkRoute(t, field, moves, route)
+					}
+					if first {
+						expected, expectedErr, first = route, err, false
+						continue
+					}
+
+					if err != expectedErr || route.Trees != expected.Trees || route.Steps != expected.Steps {
+						t.Errorf("%s, %s, tree-free %v on\n%s: expected %d trees in %d steps (%v), got %d in %d (%v)",
+							algorithm, name, treeFree, text, expected.Trees, expected.Steps, expectedErr, route.Trees, route.Steps, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestParseMoves(t *testing.T) {
+	if moves, err := ParseMoves("knight"); err != nil || !reflect.DeepEqual(moves, MoveSets["knight"]) {
+		t.Errorf("expected the knight moves, got %v (%v)", moves, err)
+	}
+	if moves, err := ParseMoves("0,1 -1,2"); err != nil || !reflect.DeepEqual(moves, []Move{{0, 1}, {-1, 2}}) {
+		t.Errorf("expected two moves, got %v (%v)", moves, err)
+	}
+
+	for _, text := range []string{"bishop", "0,1junk", "0", "1,2,3"} {
+		if moves, err := ParseMoves(text); err == nil {
+			t.Errorf("%q: expected an error, got %v", text, moves)
+		}
+	}
+}
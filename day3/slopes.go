@@ -0,0 +1,173 @@
+package day3
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type Slope struct {
+	Dx int
+	Dy int
+}
+
+func (slope Slope) String() string {
+	return fmt.Sprintf("%d,%d", slope.Dx, slope.Dy)
+}
+
+var errNoMovement = errors.New("A slope should move in at least one direction")
+
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// walk returns where the path of slope starts on a field of width by height
+// cells and how many steps it takes, see Path.
+func walk(width, height int, slope Slope, torus bool) (int, int, int, error) {
+	if slope.Dx == 0 && slope.Dy == 0 {
+		return 0, 0, 0, errNoMovement
+	}
+
+	switch {
+	case torus:
+		// The path is back at the start after the smallest number of steps
+		// that is a multiple of the period along both axes.
+		periodX := width / gcd(mod(slope.Dx, width), width)
+		periodY := height / gcd(mod(slope.Dy, height), height)
+		return 0, 0, periodX / gcd(periodX, periodY) * periodY, nil
+	case slope.Dy > 0:
+		return 0, 0, (height + slope.Dy - 1) / slope.Dy, nil
+	case slope.Dy < 0:
+		return 0, height - 1, (height - slope.Dy - 1) / -slope.Dy, nil
+	default:
+		return 0, 0, width / gcd(mod(slope.Dx, width), width), nil
+	}
+}
+
+func (field *Field) walk(slope Slope, torus bool) (int, int, int, error) {
+	return walk(len(field.Template[0]), len(field.Template), slope, torus)
+}
+
+// Path returns the cells visited following slope, as x, y pairs with x
+// wrapped into the field. Slopes going down start at the top left and end
+// at the bottom, slopes going up start at the bottom left and end at the
+// top, and horizontal slopes go around the top row once. On a torus the
+// field also wraps vertically and every path starts at the top left and
+// ends right before it gets back there.
+func (field *Field) Path(slope Slope, torus bool) ([][2]int, error) {
+	x, y, steps, err := field.walk(slope, torus)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := len(field.Template[0]), len(field.Template)
+	path := make([][2]int, steps)
+	for step := range path {
+		path[step] = [2]int{mod(x, width), mod(y, height)}
+		x += slope.Dx
+		y += slope.Dy
+	}
+	return path, nil
+}
+
+func (field *Field) countPath(path [][2]int) int {
+	trees := 0
+	for _, cell := range path {
+		if field.HasTree(cell[0], cell[1]) {
+			trees += 1
+		}
+	}
+	return trees
+}
+
+// Trees counts the trees on the path of slope, see Path.
+func (field *Field) Trees(slope Slope, torus bool) (int, error) {
+	path, err := field.Path(slope, torus)
+	if err != nil {
+		return 0, err
+	}
+	return field.countPath(path), nil
+}
+
+type SlopeResult struct {
+	Slope Slope `json:"slope"`
+	Trees int   `json:"trees"`
+	Steps int   `json:"steps"`
+}
+
+type Ranking []SlopeResult
+
+// Product multiplies the trees hit on every slope of the ranking, so that
+// the product over a selection is that of a slice of the ranking.
+func (ranking Ranking) Product() int {
+	product := 1
+	for _, result := range ranking {
+		product *= result.Trees
+	}
+	return product
+}
+
+func (ranking Ranking) Slopes() []Slope {
+	slopes := make([]Slope, len(ranking))
+	for ix, result := range ranking {
+		slopes[ix] = result.Slope
+	}
+	return slopes
+}
+
+// EvaluateSlopes counts the trees for every slope, in the order given.
+func EvaluateSlopes(field Field, slopes []Slope, torus bool) (Ranking, error) {
+	results := make(Ranking, 0, len(slopes))
+	for _, slope := range slopes {
+		path, err := field.Path(slope, torus)
+		if err != nil {
+			return nil, fmt.Errorf("Slope %s: %s", slope, err)
+		}
+
+		results = append(results, SlopeResult{Slope: slope, Trees: field.countPath(path), Steps: len(path)})
+	}
+	return results, nil
+}
+
+// SearchSlopes evaluates every slope with components between -maxDx and
+// maxDx and between -maxDy and maxDy, and ranks them by the trees hit,
+// fewest first unless most is set. Ties are broken by the number of steps,
+// more first, and then by dy and dx.
+func SearchSlopes(field Field, maxDx, maxDy int, torus, most bool) (Ranking, error) {
+	if maxDx < 0 || maxDy < 0 || (maxDx == 0 && maxDy == 0) {
+		return nil, errors.New("The slope space should hold at least one slope")
+	}
+
+	slopes := make([]Slope, 0, (2*maxDx+1)*(2*maxDy+1)-1)
+	for dy := -maxDy; dy <= maxDy; dy += 1 {
+		for dx := -maxDx; dx <= maxDx; dx += 1 {
+			if dx != 0 || dy != 0 {
+				slopes = append(slopes, Slope{Dx: dx, Dy: dy})
+			}
+		}
+	}
+
+	ranking, err := EvaluateSlopes(field, slopes, torus)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if a.Trees != b.Trees {
+			return (a.Trees < b.Trees) != most
+		}
+		return a.Steps > b.Steps
+	})
+	return ranking, nil
+}
@@ -0,0 +1,214 @@
+package day3
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func readExample(t *testing.T) Field {
+	t.Helper()
+
+	file, err := os.Open("test_input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	field, err := ReadField(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return field
+}
+
+func parseField(rows ...string) Field {
+	field := Field{Template: make([][]bool, len(rows))}
+	for ix, row := range rows {
+		field.Template[ix] = readFieldRow([]byte(row))
+	}
+	return field
+}
+
+// referenceTrees counts the trees on a slope that moves down or up the
+// field, one step at a time.
+func referenceTrees(field Field, slope Slope) int {
+	width, height := len(field.Template[0]), len(field.Template)
+	trees := 0
+	x, y := 0, 0
+	if slope.Dy < 0 {
+		y = height - 1
+	}
+
+	for y >= 0 && y < height {
+		if field.Template[y][((x%width)+width)%width] {
+			trees += 1
+		}
+		x += slope.Dx
+		y += slope.Dy
+	}
+	return trees
+}
+
+func TestSlopesSet(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected Slopes
+	}{
+		{"3,1", Slopes{{3, 1}}},
+		{"1,1 3,1  5,1", Slopes{{1, 1}, {3, 1}, {5, 1}}},
+		{"-1,-2 0,1", Slopes{{-1, -2}, {0, 1}}},
+		{"", Slopes{}},
+	}
+
+	for _, c := range cases {
+		var slopes Slopes
+		if err := slopes.Set(c.value); err != nil {
+			t.Errorf("%q: %s", c.value, err)
+		} else if !reflect.DeepEqual(slopes, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.value, c.expected, slopes)
+		}
+	}
+
+	for _, value := range []string{"3,1junk", "3", "3,1,2", "a,1", "3, 1", "3,1 0,0", "+3,0x1"} {
+		var slopes Slopes
+		if err := slopes.Set(value); err == nil {
+			t.Errorf("%q: expected an error, got %v", value, slopes)
+		}
+	}
+}
+
+func TestTrees(t *testing.T) {
+	field := readExample(t)
+
+	cases := []struct {
+		slope Slope
+		trees int
+	}{
+		{Slope{1, 1}, 2},
+		{Slope{3, 1}, 7},
+		{Slope{5, 1}, 3},
+		{Slope{7, 1}, 4},
+		{Slope{1, 2}, 2},
+	}
+
+	for _, c := range cases {
+		if trees, err := field.Trees(c.slope, false); err != nil || trees != c.trees {
+			t.Errorf("%s: expected %d trees, got %d (%v)", c.slope, c.trees, trees, err)
+		}
+	}
+
+	for _, dy := range []int{-3, -2, -1, 1, 2, 3} {
+		for dx := -12; dx <= 12; dx += 1 {
+			slope := Slope{dx, dy}
+			if trees, err := field.Trees(slope, false); err != nil || trees != referenceTrees(field, slope) {
+				t.Errorf("%s: expected %d trees, got %d (%v)", slope, referenceTrees(field, slope), trees, err)
+			}
+		}
+	}
+
+	if _, err := field.Trees(Slope{0, 0}, false); err == nil {
+		t.Errorf("expected an error for a slope that does not move")
+	}
+}
+
+func TestPath(t *testing.T) {
+	field := parseField(
+		"#...",
+		"....",
+		".#..",
+		"..#.",
+		"....",
+		"...#",
+	)
+
+	cases := []struct {
+		slope Slope
+		torus bool
+		path  [][2]int
+	}{
+		{Slope{1, 2}, false, [][2]int{{0, 0}, {1, 2}, {2, 4}}},
+		{Slope{-1, 2}, false, [][2]int{{0, 0}, {3, 2}, {2, 4}}},
+		{Slope{1, -2}, false, [][2]int{{0, 5}, {1, 3}, {2, 1}}},
+		{Slope{-1, -3}, false, [][2]int{{0, 5}, {3, 2}}},
+		{Slope{1, 0}, false, [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{Slope{2, 0}, false, [][2]int{{0, 0}, {2, 0}}},
+		{Slope{-3, 0}, false, [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{Slope{0, 7}, false, [][2]int{{0, 0}}},
+		{Slope{2, 3}, true, [][2]int{{0, 0}, {2, 3}}},
+		{Slope{1, 2}, true, [][2]int{{0, 0}, {1, 2}, {2, 4}, {3, 0}, {0, 2}, {1, 4}, {2, 0}, {3, 2}, {0, 4}, {1, 0}, {2, 2}, {3, 4}}},
+		{Slope{0, -2}, true, [][2]int{{0, 0}, {0, 4}, {0, 2}}},
+		{Slope{4, 6}, true, [][2]int{{0, 0}}},
+	}
+
+	for _, c := range cases {
+		path, err := field.Path(c.slope, c.torus)
+		if err != nil {
+			t.Errorf("%s: %s", c.slope, err)
+		} else if !reflect.DeepEqual(path, c.path) {
+			t.Errorf("%s (torus %v): expected %v, got %v", c.slope, c.torus, c.path, path)
+		}
+	}
+
+	if trees, _ := field.Trees(Slope{1, 1}, true); trees != 2 {
+		t.Errorf("expected 2 trees on the torus, got %d", trees)
+	}
+}
+
+func TestRanking(t *testing.T) {
+	field := readExample(t)
+
+	ranking, err := EvaluateSlopes(field, Slopes{{1, 1}, {3, 1}, {5, 1}, {7, 1}, {1, 2}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product := ranking.Product(); product != 336 {
+		t.Errorf("expected 336, got %d", product)
+	}
+	if product := ranking[1:3].Product(); product != 21 {
+		t.Errorf("expected 21, got %d", product)
+	}
+	if product := ranking[:0].Product(); product != 1 {
+		t.Errorf("expected 1 for no slopes, got %d", product)
+	}
+	if _, err := EvaluateSlopes(field, Slopes{{3, 1}, {0, 0}}, false); err == nil {
+		t.Errorf("expected an error for a slope that does not move")
+	}
+}
+
+func TestSearchSlopes(t *testing.T) {
+	field := readExample(t)
+
+	for _, most := range []bool{false, true} {
+		ranking, err := SearchSlopes(field, 3, 2, false, most)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ranking) != 7*5-1 {
+			t.Fatalf("expected %d slopes, got %d", 7*5-1, len(ranking))
+		}
+
+		for ix, result := range ranking {
+			if trees, _ := field.Trees(result.Slope, false); trees != result.Trees {
+				t.Errorf("%s: expected %d trees, got %d", result.Slope, trees, result.Trees)
+			}
+			if ix == 0 {
+				continue
+			}
+
+			previous := ranking[ix-1]
+			inOrder := (previous.Trees < result.Trees) != most
+			if previous.Trees == result.Trees {
+				inOrder = previous.Steps > result.Steps || (previous.Steps == result.Steps &&
+					(previous.Slope.Dy < result.Slope.Dy || (previous.Slope.Dy == result.Slope.Dy && previous.Slope.Dx < result.Slope.Dx)))
+			}
+			if !inOrder {
+				t.Errorf("most %v: %v ranked before %v", most, previous, result)
+			}
+		}
+	}
+
+	if _, err := SearchSlopes(field, 0, 0, false, false); err == nil {
+		t.Errorf("expected an error for an empty slope space")
+	}
+}
@@ -0,0 +1,116 @@
+package day4
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type Passport struct {
+	Attributes map[string]string
+}
+
+func (passport *Passport) HasAttribute(name string) bool {
+	_, ok := passport.Attributes[name]
+	return ok
+}
+
+var puzzleSchema = DefaultSchema()
+
+func (passport *Passport) IsComplete() bool {
+	return puzzleSchema.IsComplete(passport)
+}
+
+func (passport *Passport) Validate() bool {
+	return puzzleSchema.Validate(passport)
+}
+
+func CountValidPassports(passports []Passport, isValid func(*Passport) bool) int {
+	validPassports := 0
+	for _, passport := range passports {
+		if isValid(&passport) {
+			validPassports += 1
+		}
+	}
+	return validPassports
+}
+
+func ReadPassport(lines []string) (Passport, error) {
+	attributes := make(map[string]string)
+	for lineIndex, line := range lines {
+		column := 1
+		for _, property := range strings.Split(line, " ") {
+			parts := strings.SplitN(property, ":", 2)
+			if len(parts) != 2 || len(parts[0]) == 0 {
+				return Passport{}, &input.ParseError{
+					Line:   lineIndex + 1,
+					Column: column,
+					Err:    errors.New("property should be formatted as <name>:<value>"),
+				}
+			}
+
+			attributes[parts[0]] = parts[1]
+			column += len(property) + 1
+		}
+	}
+	return Passport{
+		Attributes: attributes,
+	}, nil
+}
+
+func ReadPassports(r io.Reader) ([]Passport, error) {
+	passports := make([]Passport, 0)
+
+	scanner := input.NewScanner(r)
+	for scanner.ScanRecord() {
+		passport, err := ReadPassport(scanner.Record())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		passports = append(passports, passport)
+	}
+
+	return passports, scanner.Err()
+}
+
+type Solver struct {
+	passports  []Passport
+	schemaFile string
+	schema     *Schema
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	params.StringVar(&s.schemaFile, "schema", "", "JSON file describing the passport fields, the puzzle rules when empty")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	s.schema = puzzleSchema
+	if s.schemaFile != "" {
+		schema, err := LoadSchema(s.schemaFile)
+		if err != nil {
+			return err
+		}
+		s.schema = schema
+	}
+
+	passports, err := ReadPassports(r)
+	s.passports = passports
+	return err
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Answer(CountValidPassports(s.passports, s.schema.IsComplete)), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Answer(CountValidPassports(s.passports, s.schema.Validate)), nil
+}
+
+func init() {
+	solver.Register(4, func() solver.Solver { return &Solver{} })
+}
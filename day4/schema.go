@@ -0,0 +1,215 @@
+package day4
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//go:embed schema.json
+var defaultSchema []byte
+
+// Bounds is an inclusive range, open on a side without a bound.
+type Bounds struct {
+	Min *int64 `json:"min,omitempty"`
+	Max *int64 `json:"max,omitempty"`
+}
+
+func (bounds Bounds) contains(value int64) bool {
+	return (bounds.Min == nil || value >= *bounds.Min) && (bounds.Max == nil || value <= *bounds.Max)
+}
+
+// FieldRule describes a passport field. The type decides which of the other
+// settings apply:
+//
+//	int         a base 10 number within min and max
+//	enum        one of values
+//	regex       a value matching pattern as a whole
+//	measure     a number within the bounds of the unit following it
+//	hex-colour  # followed by six hexadecimal digits
+//	digits      exactly length decimal digits
+//
+// A field without a type accepts any value.
+type FieldRule struct {
+	Name     string            `json:"name"`
+	Required bool              `json:"required"`
+	Type     string            `json:"type,omitempty"`
+	Bounds                     // of int fields
+	Values   []string          `json:"values,omitempty"`
+	Pattern  string            `json:"pattern,omitempty"`
+	Units    map[string]Bounds `json:"units,omitempty"`
+	Length   int               `json:"length,omitempty"`
+
+	validate func(value string) bool
+}
+
+type Schema struct {
+	Fields []FieldRule `json:"fields"`
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(value) > 0
+}
+
+func isHex(value string) bool {
+	for _, c := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return len(value) > 0
+}
+
+func (rule *FieldRule) compile() error {
+	switch rule.Type {
+	case "":
+		rule.validate = func(string) bool { return true }
+
+	case "int":
+		bounds := rule.Bounds
+		rule.validate = func(value string) bool {
+			number, err := strconv.ParseInt(value, 10, 64)
+			return err == nil && bounds.contains(number)
+		}
+
+	case "enum":
+		if len(rule.Values) == 0 {
+			return errors.New("an enum needs values")
+		}
+
+		values := make(map[string]bool)
+		for _, value := range rule.Values {
+			values[value] = true
+		}
+		rule.validate = func(value string) bool { return values[value] }
+
+	case "regex":
+		re, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			return err
+		}
+		rule.validate = re.MatchString
+
+	case "measure":
+		if len(rule.Units) == 0 {
+			return errors.New("a measure needs units")
+		}
+
+		units := rule.Units
+		rule.validate = func(value string) bool {
+			for unit, bounds := range units {
+				if !strings.HasSuffix(value, unit) {
+					continue
+				}
+
+				number, err := strconv.ParseInt(strings.TrimSuffix(value, unit), 10, 64)
+				if err == nil && bounds.contains(number) {
+					return true
+				}
+			}
+			return false
+		}
+
+	case "hex-colour":
+		rule.validate = func(value string) bool {
+			return len(value) == 7 && value[0] == '#' && isHex(value[1:])
+		}
+
+	case "digits":
+		if rule.Length <= 0 {
+			return errors.New("digits need a positive length")
+		}
+
+		length := rule.Length
+		rule.validate = func(value string) bool {
+			return len(value) == length && isDigits(value)
+		}
+
+	default:
+		return fmt.Errorf("unknown type %q", rule.Type)
+	}
+
+	return nil
+}
+
+func ParseSchema(data []byte) (*Schema, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+
+	var schema Schema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for ix := range schema.Fields {
+		rule := &schema.Fields[ix]
+		if rule.Name == "" {
+			return nil, fmt.Errorf("Field %d has no name", ix+1)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("Field %s is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("Field %s: %s", rule.Name, err)
+		}
+	}
+
+	return &schema, nil
+}
+
+func LoadSchema(filename string) (*Schema, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := ParseSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return schema, nil
+}
+
+// DefaultSchema returns the rules of the puzzle.
+func DefaultSchema() *Schema {
+	schema, err := ParseSchema(defaultSchema)
+	if err != nil {
+		panic(fmt.Sprintf("day4: invalid default schema: %s", err))
+	}
+	return schema
+}
+
+// IsComplete reports whether passport has every required field.
+func (schema *Schema) IsComplete(passport *Passport) bool {
+	for _, rule := range schema.Fields {
+		if rule.Required && !passport.HasAttribute(rule.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate reports whether passport has every required field and whether
+// every field it has is valid.
+func (schema *Schema) Validate(passport *Passport) bool {
+	for _, rule := range schema.Fields {
+		value, ok := passport.Attributes[rule.Name]
+		if (!ok && rule.Required) || (ok && !rule.validate(value)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package day4
+
+import (
+	"strings"
+	"testing"
+)
+
+func validPassport() *Passport {
+	return &Passport{Attributes: map[string]string{
+		"byr": "1980",
+		"iyr": "2012",
+		"eyr": "2030",
+		"hgt": "74in",
+		"hcl": "#623a2f",
+		"ecl": "grn",
+		"pid": "087499704",
+	}}
+}
+
+func TestDefaultSchema(t *testing.T) {
+	cases := []struct {
+		field string
+		value string
+		valid bool
+	}{
+		{"byr", "1919", false},
+		{"byr", "1920", true},
+		{"byr", "2002", true},
+		{"byr", "2003", false},
+		{"byr", "02002", true},
+		{"byr", "19x0", false},
+		{"iyr", "2009", false},
+		{"iyr", "2010", true},
+		{"iyr", "2020", true},
+		{"iyr", "2021", false},
+		{"eyr", "2019", false},
+		{"eyr", "2020", true},
+		{"eyr", "2030", true},
+		{"eyr", "2031", false},
+		{"hgt", "149cm", false},
+		{"hgt", "150cm", true},
+		{"hgt", "193cm", true},
+		{"hgt", "194cm", false},
+		{"hgt", "58in", false},
+		{"hgt", "59in", true},
+		{"hgt", "76in", true},
+		{"hgt", "77in", false},
+		{"hgt", "190", false},
+		{"hgt", "190in", false},
+		{"hgt", "60cm", false},
+		{"hgt", "cm", false},
+		{"hgt", "170mm", false},
+		{"hcl", "#123abc", true},
+		{"hcl", "#123ABC", true},
+		{"hcl", "#123abz", false},
+		{"hcl", "123abc", false},
+		{"hcl", "#123ab", false},
+		{"hcl", "#123abcd", false},
+		{"hcl", "#+12345", false},
+		{"ecl", "brn", true},
+		{"ecl", "oth", true},
+		{"ecl", "wat", false},
+		{"ecl", "", false},
+		{"pid", "000000001", true},
+		{"pid", "0123456789", false},
+		{"pid", "01234567", false},
+		{"pid", "01234567a", false},
+		{"pid", "+12345678", false},
+		{"cid", "anything", true},
+	}
+
+	schema := DefaultSchema()
+	for _, c := range cases {
+		passport := validPassport()
+		passport.Attributes[c.field] = c.value
+		if valid := schema.Validate(passport); valid != c.valid {
+			t.Errorf("%s:%s: expected valid %v, got %v", c.field, c.value, c.valid, valid)
+		}
+	}
+
+	passport := validPassport()
+	if !schema.IsComplete(passport) || !schema.Validate(passport) {
+		t.Errorf("expected %v to be valid", passport.Attributes)
+	}
+
+	delete(passport.Attributes, "hgt")
+	if schema.IsComplete(passport) || schema.Validate(passport) {
+		t.Errorf("expected a passport without hgt to be incomplete")
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{"fields": [
+		{"name": "pid", "required": true, "type": "regex", "pattern": "[0-9]{3}|x"},
+		{"name": "age", "type": "int", "min": 18},
+		{"name": "note"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		attributes map[string]string
+		complete   bool
+		valid      bool
+	}{
+		{map[string]string{"pid": "123"}, true, true},
+		{map[string]string{"pid": "x", "age": "18", "note": ""}, true, true},
+		{map[string]string{"pid": "1234"}, true, false},
+		{map[string]string{"pid": "0x"}, true, false},
+		{map[string]string{"pid": "123", "age": "17"}, true, false},
+		{map[string]string{"pid": "123", "age": "9999999999"}, true, true},
+		{map[string]string{"age": "30"}, false, false},
+	}
+
+	for _, c := range cases {
+		passport := &Passport{Attributes: c.attributes}
+		if complete, valid := schema.IsComplete(passport), schema.Validate(passport); complete != c.complete || valid != c.valid {
+			t.Errorf("%v: expected complete %v and valid %v, got %v and %v", c.attributes, c.complete, c.valid, complete, valid)
+		}
+	}
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	cases := []struct {
+		schema  string
+		message string
+	}{
+		{`{"fields": [{"name": "byr", "type": "year"}]}`, `Field byr: unknown type "year"`},
+		{`{"fields": [{"name": "byr"}, {"name": "byr"}]}`, "Field byr is defined twice"},
+		{`{"fields": [{"name": "byr", "maximum": 3}]}`, `unknown field "maximum"`},
+		{`{"fields": [{"type": "int"}]}`, "Field 1 has no name"},
+		{`{"fields": [{"name": "ecl", "type": "enum"}]}`, "Field ecl: an enum needs values"},
+		{`{"fields": [{"name": "pid", "type": "digits"}]}`, "Field pid: digits need a positive length"},
+		{`{"fields": [{"name": "hgt", "type": "measure"}]}`, "Field hgt: a measure needs units"},
+		{`{"fields": [{"name": "hcl", "type": "regex", "pattern": "("}]}`, "Field hcl: error parsing regexp"},
+		{`{"fields": [`, "unexpected EOF"},
+	}
+
+	for _, c := range cases {
+		if _, err := ParseSchema([]byte(c.schema)); err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%s: expected %q, got %v", c.schema, c.message, err)
+		}
+	}
+
+	if _, err := LoadSchema("missing.json"); err == nil {
+		t.Errorf("expected an error loading a missing schema")
+	}
+}
@@ -0,0 +1,157 @@
+package day5
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+const (
+	COLUMNS_PER_ROW = 8
+)
+
+type BoardingPass struct {
+	code string
+}
+
+func decode(code string, lc, uc rune) int {
+	lower := 0
+	upper := int(math.Pow(2, float64(len(code))) - 1)
+	for _, c := range code {
+		switch c {
+		case lc:
+			upper = lower + (upper-lower)/2
+			break
+
+		case uc:
+			lower = lower + (upper-lower)/2 + 1
+			break
+		default:
+			break
+		}
+	}
+
+	return lower
+}
+
+func (pass *BoardingPass) decodeRow() int {
+	rowCode := pass.code[:len(pass.code)-3]
+	return decode(rowCode, 'F', 'B')
+}
+
+func (pass *BoardingPass) decodeColumn() int {
+	columnCode := pass.code[len(pass.code)-3:]
+	return decode(columnCode, 'L', 'R')
+}
+
+func (pass *BoardingPass) SeatId() int {
+	row := pass.decodeRow()
+	column := pass.decodeColumn()
+
+	return row*COLUMNS_PER_ROW + column
+}
+
+func ReadBoardingPasses(r io.Reader) ([]BoardingPass, error) {
+	boardingPasses := make([]BoardingPass, 0)
+
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		pass, err := ReadBoardingPass(scanner.Text())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		boardingPasses = append(boardingPasses, pass)
+	}
+
+	return boardingPasses, scanner.Err()
+}
+
+func ReadBoardingPass(line string) (BoardingPass, error) {
+	if len(line) <= 3 {
+		return BoardingPass{}, input.Errorf(0, "Boarding pass should have a row and a column code")
+	}
+
+	for ix, c := range line {
+		expected := "FB"
+		if ix >= len(line)-3 {
+			expected = "LR"
+		}
+
+		if !strings.ContainsRune(expected, c) {
+			return BoardingPass{}, input.Errorf(ix+1, "Invalid code %q, expected one of %q", c, expected)
+		}
+	}
+
+	return BoardingPass{
+		code: line,
+	}, nil
+}
+
+func FindMaxSeatId(boardingPasses []BoardingPass) int {
+
+	maxSeatId := -1
+	for _, pass := range boardingPasses {
+		seatId := pass.SeatId()
+		if seatId > maxSeatId {
+			maxSeatId = seatId
+		}
+	}
+
+	return maxSeatId
+}
+
+func FindMySeatId(boardingPasses []BoardingPass) int {
+	allSeats := make([]bool, FindMaxSeatId(boardingPasses)+1)
+
+	for _, pass := range boardingPasses {
+		allSeats[pass.SeatId()] = true
+	}
+
+	for ix := 1; ix < len(allSeats)-1; ix += 1 {
+		if allSeats[ix-1] && !allSeats[ix] && allSeats[ix+1] {
+			return ix
+		}
+	}
+
+	return -1
+}
+
+type Solver struct {
+	boardingPasses []BoardingPass
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	boardingPasses, err := ReadBoardingPasses(r)
+	s.boardingPasses = boardingPasses
+	return err
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	if len(s.boardingPasses) == 0 {
+		return 0, errors.New("No boarding passes found")
+	}
+
+	return solver.Answer(FindMaxSeatId(s.boardingPasses)), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	if len(s.boardingPasses) == 0 {
+		return 0, errors.New("No boarding passes found")
+	}
+
+	seatId := FindMySeatId(s.boardingPasses)
+	if seatId < 0 {
+		return 0, errors.New("No free seat found")
+	}
+
+	return solver.Answer(seatId), nil
+}
+
+func init() {
+	solver.Register(5, func() solver.Solver { return &Solver{} })
+}
@@ -0,0 +1,120 @@
+package day6
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type GroupResponse struct {
+	answers []string
+}
+
+func ReadGroupResponse(lines []string) (GroupResponse, error) {
+	for lineIndex, line := range lines {
+		for ix, c := range line {
+			if c < 'a' || c > 'z' {
+				return GroupResponse{}, &input.ParseError{
+					Line:   lineIndex + 1,
+					Column: ix + 1,
+					Err:    fmt.Errorf("Invalid question %q", c),
+				}
+			}
+		}
+	}
+
+	return GroupResponse{
+		answers: lines,
+	}, nil
+}
+
+func ReadGroupResponses(r io.Reader) ([]GroupResponse, error) {
+	responses := make([]GroupResponse, 0)
+
+	scanner := input.NewScanner(r)
+	for scanner.ScanRecord() {
+		response, err := ReadGroupResponse(scanner.Record())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, scanner.Err()
+}
+
+func (response *GroupResponse) Responses() map[string]int {
+	questions := make(map[string]int)
+
+	for _, response := range response.answers {
+		for _, question := range response {
+			questions[string(question)] += 1
+		}
+	}
+
+	return questions
+}
+
+func (response *GroupResponse) Size() int {
+	return len(response.answers)
+}
+
+func (response *GroupResponse) UnanymousResponseCount() int {
+	unanymousCount := 0
+	size := response.Size()
+
+	for _, count := range response.Responses() {
+		if count == size {
+			unanymousCount += 1
+		}
+	}
+
+	return unanymousCount
+}
+
+func (response *GroupResponse) PositiveResponseCount() int {
+	return len(response.Responses())
+}
+
+func SumPositiveResponsesByGroup(responses []GroupResponse) int {
+	sum := 0
+
+	for _, response := range responses {
+		sum += response.PositiveResponseCount()
+	}
+
+	return sum
+}
+
+func SumUnanymousPositiveResponseByGroup(responses []GroupResponse) int {
+	sum := 0
+	for _, response := range responses {
+		sum += response.UnanymousResponseCount()
+	}
+	return sum
+}
+
+type Solver struct {
+	responses []GroupResponse
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	responses, err := ReadGroupResponses(r)
+	s.responses = responses
+	return err
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Answer(SumPositiveResponsesByGroup(s.responses)), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Answer(SumUnanymousPositiveResponseByGroup(s.responses)), nil
+}
+
+func init() {
+	solver.Register(6, func() solver.Solver { return &Solver{} })
+}
@@ -0,0 +1,145 @@
+package day7
+
+import (
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type Specification struct {
+	color    string
+	contents map[string]int
+}
+
+func ReadSpecifications(r io.Reader) ([]Specification, error) {
+	specifications := make([]Specification, 0)
+
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		specification, err := ReadSpecification(scanner.Text())
+		if err != nil {
+			return specifications, scanner.Wrap(err)
+		}
+
+		specifications = append(specifications, specification)
+	}
+
+	return specifications, scanner.Err()
+}
+
+func wordColumn(words []string, index int) int {
+	column := 1
+	for _, word := range words[:index] {
+		column += len(word) + 1
+	}
+	return column
+}
+
+func ReadSpecification(line string) (Specification, error) {
+	words := strings.Split(line, " ")
+	if len(words) < 7 || strings.Join(words[2:4], " ") != "bags contain" {
+		return Specification{}, input.Errorf(0, "specification should be formatted as <color> bags contain <contents>")
+	}
+
+	target := strings.Join(words[:2], " ")
+
+	specification := Specification{
+		color:    target,
+		contents: make(map[string]int),
+	}
+	for ix := 4; ix < len(words); ix += 4 {
+		if ix+3 > len(words) {
+			return specification, input.Errorf(wordColumn(words, ix), "contents should be formatted as <count> <color> bags")
+		}
+
+		if strings.Join(words[ix:ix+3], " ") == "no other bags." {
+			break
+		}
+
+		count, err := input.ParseInt(words[ix], wordColumn(words, ix), 32)
+		if err != nil {
+			return specification, err
+		}
+
+		contentColor := strings.Join(words[ix+1:ix+3], " ")
+		specification.contents[contentColor] = int(count)
+	}
+
+	return specification, nil
+}
+
+func CanContainBag(specifications []Specification, current string, target string) bool {
+	for _, specification := range specifications {
+		if specification.color != current {
+			continue
+		}
+
+		for content := range specification.contents {
+			if content == target {
+				return true
+			}
+
+			if CanContainBag(specifications, content, target) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func CountPossibleBags(specifications []Specification, target string) int {
+	possible := 0
+
+	for _, specification := range specifications {
+		if CanContainBag(specifications, specification.color, target) {
+			possible += 1
+		}
+	}
+
+	return possible
+}
+
+func CountRequiredBags(specifications []Specification, target string) int {
+	total := 0
+	for _, specification := range specifications {
+		if specification.color != target {
+			continue
+		}
+
+		for content, count := range specification.contents {
+			total += count * (1 + CountRequiredBags(specifications, content))
+		}
+	}
+	return total
+}
+
+type Solver struct {
+	specifications []Specification
+	target         string
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	params.StringVar(&s.target, "target", "shiny gold", "color of the bag to pack")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	specifications, err := ReadSpecifications(r)
+	s.specifications = specifications
+	return err
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Answer(CountPossibleBags(s.specifications, s.target)), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Answer(CountRequiredBags(s.specifications, s.target)), nil
+}
+
+func init() {
+	solver.Register(7, func() solver.Solver { return &Solver{} })
+}
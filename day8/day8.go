@@ -0,0 +1,201 @@
+package day8
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+type Machine struct {
+	accumulator         int
+	instruction_pointer int
+}
+
+type Instruction interface {
+	process(machine *Machine)
+}
+
+type NoOperation struct {
+	argument int
+}
+
+func (operation NoOperation) process(machine *Machine) {
+	machine.instruction_pointer += 1
+}
+
+type Jump struct {
+	argument int
+}
+
+func (operation Jump) process(machine *Machine) {
+	machine.instruction_pointer += operation.argument
+}
+
+type Accumulate struct {
+	argument int
+}
+
+func (operation Accumulate) process(machine *Machine) {
+	machine.accumulator += operation.argument
+	machine.instruction_pointer += 1
+}
+
+func ReadInstructions(r io.Reader) ([]Instruction, error) {
+	instructions := make([]Instruction, 0)
+
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		instruction, err := ReadInstruction(scanner.Text())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions, scanner.Err()
+}
+
+func ReadInstruction(line string) (Instruction, error) {
+	parts := strings.Split(line, " ")
+	if len(parts) != 2 {
+		return nil, input.Errorf(0, "instruction should be formatted as <operation> <argument>")
+	}
+
+	argument, err := input.ParseInt(parts[1], len(parts[0])+2, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parts[0] {
+	case "nop":
+		return NoOperation{
+			argument: int(argument),
+		}, nil
+	case "acc":
+		return Accumulate{
+			argument: int(argument),
+		}, nil
+	case "jmp":
+		return Jump{
+			argument: int(argument),
+		}, nil
+	}
+
+	return nil, input.Errorf(1, "Unrecognized operation: %s", parts[0])
+}
+
+// FindLoop runs the program until an instruction is about to run a second
+// time, in which case it reports a loop, or until the instruction pointer
+// moves past the last instruction. Jumping before the first instruction is an
+// error.
+func FindLoop(machine *Machine, instructions []Instruction) (bool, error) {
+	visited := make(map[int]bool)
+	current := machine.instruction_pointer
+
+	for current < len(instructions) {
+		if current < 0 {
+			return false, fmt.Errorf("Instruction pointer %d is before the first instruction", current)
+		}
+
+		if _, ok := visited[current]; !ok {
+			visited[current] = true
+			instructions[current].process(machine)
+			current = machine.instruction_pointer
+		} else {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (machine *Machine) Accumulator() int {
+	return machine.accumulator
+}
+
+func (machine *Machine) Reset() {
+	machine.instruction_pointer = 0
+	machine.accumulator = 0
+}
+
+func SwapInstruction(instruction Instruction) Instruction {
+	switch instruction.(type) {
+	case NoOperation:
+		return Jump{
+			argument: instruction.(NoOperation).argument,
+		}
+	case Jump:
+		return NoOperation{
+			argument: instruction.(Jump).argument,
+		}
+	default:
+		return instruction
+	}
+}
+
+func TryFixLoop(machine *Machine, instructions []Instruction) int {
+	for ix, original := range instructions {
+		machine.Reset()
+
+		instructions[ix] = SwapInstruction(original)
+		loops, err := FindLoop(machine, instructions)
+		instructions[ix] = original
+
+		if !loops && err == nil {
+			return ix
+		}
+	}
+
+	return -1
+}
+
+type Solver struct {
+	instructions []Instruction
+	details      map[int]solver.Details
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	instructions, err := ReadInstructions(r)
+	s.instructions = instructions
+	return err
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	machine := Machine{}
+	loops, err := FindLoop(&machine, s.instructions)
+	if err != nil {
+		return 0, err
+	}
+	if !loops {
+		return 0, errors.New("Program terminates without looping")
+	}
+
+	return solver.Answer(machine.Accumulator()), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	machine := Machine{}
+	fixedInstruction := TryFixLoop(&machine, s.instructions)
+	if fixedInstruction < 0 {
+		return 0, errors.New("No single instruction swap fixes the loop")
+	}
+
+	s.details[2] = solver.Details{"fixed_instruction": fixedInstruction}
+
+	return solver.Answer(machine.Accumulator()), nil
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
+
+func init() {
+	solver.Register(8, func() solver.Solver {
+		return &Solver{details: make(map[int]solver.Details)}
+	})
+}
@@ -0,0 +1,139 @@
+package day9
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"sort"
+
+	"github.com/evido/adventofcode2020/input"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func ReadData(r io.Reader) ([]int64, error) {
+	return input.Int64s(r)
+}
+
+func IsValid(terms []int64, target int64) bool {
+	for ix1, term1 := range terms {
+		for ix2, term2 := range terms {
+			if ix1 == ix2 {
+				continue
+			}
+
+			if term1+term2 == target {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func FindInvalidElement(data []int64, preamble, context int) int {
+	for ix := preamble; ix < len(data); ix += 1 {
+		if !IsValid(data[ix-context:ix], data[ix]) {
+			return ix
+		}
+	}
+
+	return -1
+}
+
+func FindTerms(data []int64, target int64) []int64 {
+	lower := 0
+	upper := 0
+	current := int64(0)
+
+	for upper < len(data) {
+		if current > target {
+			current -= data[lower]
+			lower += 1
+		} else if current < target {
+			current += data[upper]
+			upper += 1
+		} else {
+			break
+		}
+	}
+
+	return data[lower:upper]
+}
+
+func FindWeakness(terms []int64) int64 {
+	terms = append([]int64(nil), terms...)
+	sort.Slice(terms, func(i, j int) bool {
+		return terms[i] < terms[j]
+	})
+
+	return terms[0] + terms[len(terms)-1]
+}
+
+type Solver struct {
+	data     []int64
+	preamble int
+	context  int
+	details  map[int]solver.Details
+}
+
+func (s *Solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.preamble, "preamble", 25, "number of leading elements that are not validated")
+	params.IntVar(&s.context, "context", 25, "number of preceding elements an element should be the sum of two of")
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	data, err := ReadData(r)
+	s.data = data
+	return err
+}
+
+func (s *Solver) findInvalidElement() (int, error) {
+	if s.context > s.preamble {
+		return 0, errors.New("The context cannot be larger than the preamble")
+	}
+
+	invalidElementIndex := FindInvalidElement(s.data, s.preamble, s.context)
+	if invalidElementIndex < 0 {
+		return 0, errors.New("All data elements are valid")
+	}
+
+	return invalidElementIndex, nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	invalidElementIndex, err := s.findInvalidElement()
+	if err != nil {
+		return 0, err
+	}
+
+	s.details[1] = solver.Details{"index": invalidElementIndex}
+	return solver.Answer(s.data[invalidElementIndex]), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	invalidElementIndex, err := s.findInvalidElement()
+	if err != nil {
+		return 0, err
+	}
+
+	terms := FindTerms(s.data, s.data[invalidElementIndex])
+	if len(terms) < 2 {
+		return 0, errors.New("No contiguous range sums to the invalid element")
+	}
+
+	s.details[2] = solver.Details{
+		"invalid_element": s.data[invalidElementIndex],
+		"terms":           len(terms),
+	}
+	return solver.Answer(FindWeakness(terms)), nil
+}
+
+func (s *Solver) Details(part int) solver.Details {
+	return s.details[part]
+}
+
+func init() {
+	solver.Register(9, func() solver.Solver {
+		return &Solver{details: make(map[int]solver.Details)}
+	})
+}
@@ -0,0 +1,40 @@
+package days_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func BenchmarkDays(b *testing.B) {
+	for _, day := range solver.Days() {
+		dir := filepath.Join("..", fmt.Sprintf("day%d", day))
+		expectations, err := solver.LoadExpectations(filepath.Join(dir, "expected.json"))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, expectation := range expectations {
+			data, err := ioutil.ReadFile(filepath.Join(dir, expectation.Input))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(fmt.Sprintf("day%d/%s/%s", day, expectation.Input, solver.PhaseParse),
+				solver.Benchmark(day, solver.PhaseParse, data, expectation.Params))
+
+			for part := 1; part <= solver.Parts; part += 1 {
+				if _, ok := expectation.Answer(part); !ok {
+					continue
+				}
+
+				phase := solver.Phases[part]
+				b.Run(fmt.Sprintf("day%d/%s/%s", day, expectation.Input, phase),
+					solver.Benchmark(day, phase, data, expectation.Params))
+			}
+		}
+	}
+}
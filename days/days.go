@@ -0,0 +1,17 @@
+package days
+
+import (
+	_ "github.com/evido/adventofcode2020/day1"
+	_ "github.com/evido/adventofcode2020/day10"
+	_ "github.com/evido/adventofcode2020/day11"
+	_ "github.com/evido/adventofcode2020/day12"
+	_ "github.com/evido/adventofcode2020/day13"
+	_ "github.com/evido/adventofcode2020/day2"
+	_ "github.com/evido/adventofcode2020/day3"
+	_ "github.com/evido/adventofcode2020/day4"
+	_ "github.com/evido/adventofcode2020/day5"
+	_ "github.com/evido/adventofcode2020/day6"
+	_ "github.com/evido/adventofcode2020/day7"
+	_ "github.com/evido/adventofcode2020/day8"
+	_ "github.com/evido/adventofcode2020/day9"
+)
@@ -0,0 +1,35 @@
+package days_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/evido/adventofcode2020/days"
+	"github.com/evido/adventofcode2020/solver"
+)
+
+func TestRegression(t *testing.T) {
+	for _, day := range solver.Days() {
+		dir := filepath.Join("..", fmt.Sprintf("day%d", day))
+		expectations, err := solver.LoadExpectations(filepath.Join(dir, "expected.json"))
+		if err != nil {
+			t.Errorf("day %d: %s", day, err)
+			continue
+		}
+
+		for _, expectation := range expectations {
+			day, expectation := day, expectation
+			t.Run(fmt.Sprintf("day%d/%s", day, expectation.Input), func(t *testing.T) {
+				for _, check := range solver.Verify(day, filepath.Join(dir, expectation.Input), expectation) {
+					switch check.Status {
+					case solver.FAIL, solver.ERROR:
+						t.Error(check)
+					case solver.SKIP:
+						t.Logf("%s", check)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/evido/adventofcode2020
+
+go 1.16
@@ -0,0 +1,48 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError describes malformed input. Line and Column are 1-based; a zero
+// Column means the whole line is at fault.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Text   string
+	Err    error
+}
+
+// Errorf reports a problem at column of the line being parsed. Line parsers
+// return it without a position; the Scanner that read the line fills in the
+// file name, line number and text through Wrap.
+func Errorf(column int, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Column: column,
+		Err:    fmt.Errorf(format, args...),
+	}
+}
+
+func (e *ParseError) Error() string {
+	position := make([]string, 0, 3)
+	if e.File != "" {
+		position = append(position, e.File)
+	}
+	if e.Line > 0 {
+		position = append(position, fmt.Sprint(e.Line))
+	}
+	if e.Line > 0 && e.Column > 0 {
+		position = append(position, fmt.Sprint(e.Column))
+	}
+
+	if len(position) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(position, ":"), e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,265 @@
+package input
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Scanner reads puzzle input line by line. Line terminators may be either
+// "\n" or "\r\n" and the last line does not need one. Blank lines never
+// produce a line of their own: Scan skips them and ScanRecord uses them to
+// separate records.
+type Scanner struct {
+	scanner *bufio.Scanner
+	name    string
+	line    int
+	text    string
+	start   int
+	record  []string
+}
+
+func NewScanner(r io.Reader) *Scanner {
+	scanner := &Scanner{
+		scanner: bufio.NewScanner(r),
+	}
+
+	if named, ok := r.(interface{ Name() string }); ok {
+		scanner.name = named.Name()
+	}
+
+	return scanner
+}
+
+func (s *Scanner) next() bool {
+	if !s.scanner.Scan() {
+		return false
+	}
+
+	s.line += 1
+	s.text = strings.TrimSuffix(s.scanner.Text(), "\r")
+	return true
+}
+
+func isBlank(line string) bool {
+	return len(strings.TrimSpace(line)) == 0
+}
+
+func (s *Scanner) Scan() bool {
+	for s.next() {
+		if !isBlank(s.text) {
+			s.start = s.line
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Scanner) ScanRecord() bool {
+	if !s.Scan() {
+		return false
+	}
+
+	s.record = []string{s.text}
+	for s.next() && !isBlank(s.text) {
+		s.record = append(s.record, s.text)
+	}
+
+	return true
+}
+
+// Buffer sets the maximum length of a line, which is 64KiB by default. It
+// must be called before the first call to Scan.
+func (s *Scanner) Buffer(max int) {
+	s.scanner.Buffer(make([]byte, 0, 64*1024), max)
+}
+
+// Name returns the name of the file being read, if the reader has one.
+func (s *Scanner) Name() string {
+	return s.name
+}
+
+// Text returns the line read by the last call to Scan.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Record returns the lines read by the last call to ScanRecord.
+func (s *Scanner) Record() []string {
+	return s.record
+}
+
+// Line returns the 1-based line number of the last line returned by Scan or
+// of the first line of the last record returned by ScanRecord.
+func (s *Scanner) Line() int {
+	return s.start
+}
+
+func (s *Scanner) Err() error {
+	return s.scanner.Err()
+}
+
+// Wrap attaches the position of the current line or record to err. A
+// ParseError returned by a record parser may carry a line number relative to
+// the start of the record and the text of the offending line.
+func (s *Scanner) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	parseError := &ParseError{Err: err}
+	if e, ok := err.(*ParseError); ok {
+		copied := *e
+		parseError = &copied
+	}
+
+	parseError.File = s.name
+	if parseError.Line > 0 {
+		parseError.Line = s.start + parseError.Line - 1
+	} else {
+		parseError.Line = s.start
+	}
+
+	if parseError.Text == "" {
+		if s.record != nil && parseError.Line-s.start < len(s.record) {
+			parseError.Text = s.record[parseError.Line-s.start]
+		} else {
+			parseError.Text = s.text
+		}
+	}
+
+	return parseError
+}
+
+func Lines(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
+
+func Records(r io.Reader) ([][]string, error) {
+	records := make([][]string, 0)
+
+	scanner := NewScanner(r)
+	for scanner.ScanRecord() {
+		records = append(records, scanner.Record())
+	}
+
+	return records, scanner.Err()
+}
+
+// ParseInt parses text as a base 10 integer, reporting the column at which
+// the offending text starts within the line.
+func ParseInt(text string, column int, bitSize int) (int64, error) {
+	value, err := strconv.ParseInt(text, 10, bitSize)
+	if err != nil {
+		return 0, Errorf(column, "Invalid number %q: %s", text, err.(*strconv.NumError).Err)
+	}
+	return value, nil
+}
+
+// ParsePair parses two base 10 integers formatted as <a>,<b>.
+func ParsePair(text string) (int, int, bool) {
+	parts := strings.SplitN(text, ",", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	a, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	b, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return a, b, true
+}
+
+func Int64s(r io.Reader) ([]int64, error) {
+	values := make([]int64, 0)
+
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		text := strings.TrimSpace(line)
+		value, err := ParseInt(text, strings.Index(line, text)+1, 64)
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, scanner.Err()
+}
+
+func Ints(r io.Reader) ([]int, error) {
+	values, err := Int64s(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ints := make([]int, len(values))
+	for ix, value := range values {
+		ints[ix] = int(value)
+	}
+
+	return ints, nil
+}
+
+func SplitComma(line string) []string {
+	items := strings.Split(line, ",")
+	for ix, item := range items {
+		items[ix] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+func CommaList(r io.Reader) ([]string, error) {
+	items := make([]string, 0)
+
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		items = append(items, SplitComma(scanner.Text())...)
+	}
+
+	return items, scanner.Err()
+}
+
+// Grid reads a rectangular block of characters. When alphabet is not empty
+// every character must be one of its characters.
+func Grid(r io.Reader, alphabet string) ([][]byte, error) {
+	grid := make([][]byte, 0)
+
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			column := len(grid[0]) + 1
+			if len(line) < len(grid[0]) {
+				column = len(line) + 1
+			}
+			return nil, scanner.Wrap(Errorf(column, "All lines should have width %d", len(grid[0])))
+		}
+
+		if len(alphabet) > 0 {
+			for ix := 0; ix < len(line); ix += 1 {
+				if strings.IndexByte(alphabet, line[ix]) < 0 {
+					return nil, scanner.Wrap(Errorf(ix+1, "Invalid character %q, expected one of %q", line[ix], alphabet))
+				}
+			}
+		}
+
+		grid = append(grid, []byte(line))
+	}
+
+	return grid, scanner.Err()
+}
@@ -0,0 +1,199 @@
+package input
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type namedReader struct {
+	*strings.Reader
+	name string
+}
+
+func (r namedReader) Name() string {
+	return r.name
+}
+
+func TestLines(t *testing.T) {
+	cases := []struct {
+		text  string
+		lines []string
+	}{
+		{"", []string{}},
+		{"a\nb\n", []string{"a", "b"}},
+		{"a\nb", []string{"a", "b"}},
+		{"a\r\nb\r\n", []string{"a", "b"}},
+		{"a\r\nb", []string{"a", "b"}},
+		{"\n\na\n\n \t\nb\n\n", []string{"a", "b"}},
+		{" a \r\n", []string{" a "}},
+	}
+
+	for _, c := range cases {
+		lines, err := Lines(strings.NewReader(c.text))
+		if err != nil || !reflect.DeepEqual(lines, c.lines) {
+			t.Errorf("%q: expected %q, got %q (%v)", c.text, c.lines, lines, err)
+		}
+	}
+}
+
+func TestRecords(t *testing.T) {
+	cases := []struct {
+		text    string
+		records [][]string
+	}{
+		{"", [][]string{}},
+		{"a\nb\n\nc\n", [][]string{{"a", "b"}, {"c"}}},
+		{"a\nb\n\nc", [][]string{{"a", "b"}, {"c"}}},
+		{"a\r\nb\r\n\r\nc\r\n", [][]string{{"a", "b"}, {"c"}}},
+		{"\n\na\n\n\n\nb\n  \nc\n\n", [][]string{{"a"}, {"b"}, {"c"}}},
+	}
+
+	for _, c := range cases {
+		records, err := Records(strings.NewReader(c.text))
+		if err != nil || !reflect.DeepEqual(records, c.records) {
+			t.Errorf("%q: expected %q, got %q (%v)", c.text, c.records, records, err)
+		}
+	}
+}
+
+func TestInt64s(t *testing.T) {
+	values, err := Int64s(strings.NewReader(" 12 \n\n-3\r\n9223372036854775807"))
+	if err != nil || !reflect.DeepEqual(values, []int64{12, -3, 9223372036854775807}) {
+		t.Errorf("unexpected values %v (%v)", values, err)
+	}
+
+	cases := []struct {
+		text    string
+		line    int
+		column  int
+		content string
+	}{
+		{"1\n 2x\n", 2, 2, " 2x"},
+		{"1\r\n\r\n9223372036854775808\r\n", 3, 1, "9223372036854775808"},
+	}
+
+	for _, c := range cases {
+		_, err := Int64s(namedReader{strings.NewReader(c.text), "input.txt"})
+
+		var parseError *ParseError
+		if !errors.As(err, &parseError) {
+			t.Errorf("%q: expected a parse error, got %v", c.text, err)
+			continue
+		}
+		if parseError.File != "input.txt" || parseError.Line != c.line || parseError.Column != c.column || parseError.Text != c.content {
+			t.Errorf("%q: expected input.txt:%d:%d %q, got %+v", c.text, c.line, c.column, c.content, parseError)
+		}
+	}
+}
+
+func TestParsePair(t *testing.T) {
+	cases := []struct {
+		text string
+		a    int
+		b    int
+		ok   bool
+	}{
+		{"3,1", 3, 1, true},
+		{"-1,-2", -1, -2, true},
+		{"+3,0", 3, 0, true},
+		{"3", 0, 0, false},
+		{"3,1,2", 0, 0, false},
+		{"3, 1", 0, 0, false},
+		{"3,1junk", 0, 0, false},
+		{",1", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, c := range cases {
+		if a, b, ok := ParsePair(c.text); a != c.a || b != c.b || ok != c.ok {
+			t.Errorf("%q: expected %d, %d (%v), got %d, %d (%v)", c.text, c.a, c.b, c.ok, a, b, ok)
+		}
+	}
+}
+
+func TestGrid(t *testing.T) {
+	grid, err := Grid(strings.NewReader("..#\r\n#..\r\n\r\n"), ".#")
+	if err != nil || !reflect.DeepEqual(grid, [][]byte{[]byte("..#"), []byte("#..")}) {
+		t.Errorf("unexpected grid %q (%v)", grid, err)
+	}
+
+	if grid, err := Grid(strings.NewReader("abc\nxyz"), ""); err != nil || len(grid) != 2 {
+		t.Errorf("expected any character without an alphabet, got %q (%v)", grid, err)
+	}
+
+	cases := []struct {
+		text    string
+		line    int
+		column  int
+		message string
+	}{
+		{"..#\n.#\n", 2, 3, "width 3"},
+		{"..#\n....\n", 2, 4, "width 3"},
+		{"..#\n\n.x.\n", 3, 2, "Invalid character 'x'"},
+	}
+
+	for _, c := range cases {
+		_, err := Grid(strings.NewReader(c.text), ".#")
+
+		var parseError *ParseError
+		if !errors.As(err, &parseError) {
+			t.Errorf("%q: expected a parse error, got %v", c.text, err)
+			continue
+		}
+		if parseError.Line != c.line || parseError.Column != c.column || !strings.Contains(parseError.Err.Error(), c.message) {
+			t.Errorf("%q: expected %q at %d:%d, got %s", c.text, c.message, c.line, c.column, parseError)
+		}
+	}
+}
+
+func TestScannerWrap(t *testing.T) {
+	scanner := NewScanner(namedReader{strings.NewReader("\n\na\nb\n\n\nc\r\nd\r\ne\r\n"), "passports.txt"})
+
+	if scanner.Wrap(nil) != nil {
+		t.Errorf("expected nil for a nil error")
+	}
+
+	if !scanner.ScanRecord() || scanner.Line() != 3 {
+		t.Fatalf("expected a record at line 3, got %d", scanner.Line())
+	}
+	if !scanner.ScanRecord() || scanner.Line() != 7 || !reflect.DeepEqual(scanner.Record(), []string{"c", "d", "e"}) {
+		t.Fatalf("expected c, d and e at line 7, got %q at %d", scanner.Record(), scanner.Line())
+	}
+
+	cause := errors.New("bad")
+	cases := []struct {
+		err    error
+		line   int
+		column int
+		text   string
+	}{
+		{cause, 7, 0, "c"},
+		{Errorf(4, "bad"), 7, 4, "c"},
+		{&ParseError{Line: 2, Column: 1, Err: cause}, 8, 1, "d"},
+		{&ParseError{Line: 3, Err: cause}, 9, 0, "e"},
+		{&ParseError{Line: 2, Text: "custom", Err: cause}, 8, 0, "custom"},
+	}
+
+	for _, c := range cases {
+		var parseError *ParseError
+		if !errors.As(scanner.Wrap(c.err), &parseError) {
+			t.Fatalf("expected a parse error for %v", c.err)
+		}
+
+		if parseError.File != "passports.txt" || parseError.Line != c.line || parseError.Column != c.column || parseError.Text != c.text {
+			t.Errorf("%v: expected passports.txt:%d:%d %q, got %+v", c.err, c.line, c.column, c.text, parseError)
+		}
+	}
+
+	original := &ParseError{Line: 2, Err: cause}
+	scanner.Wrap(original)
+	if original.Line != 2 {
+		t.Errorf("Wrap modified the error it was given")
+	}
+
+	if scanner.ScanRecord() {
+		t.Errorf("expected no more records, got %q", scanner.Record())
+	}
+}
@@ -0,0 +1,158 @@
+package solver
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	PhaseParse = "parse"
+	PhasePart1 = "part1"
+	PhasePart2 = "part2"
+)
+
+var Phases = []string{PhaseParse, PhasePart1, PhasePart2}
+
+type Measurement struct {
+	Day         int     `json:"day"`
+	Phase       string  `json:"phase"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	PeakHeap    uint64  `json:"peak_heap"`
+}
+
+func parse(day int, data []byte, params Params) (Solver, error) {
+	s, err := New(day)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Configure(s, params); err != nil {
+		return nil, err
+	}
+
+	return s, s.Parse(bytes.NewReader(data))
+}
+
+// operation prepares a single run of one phase of day against data, solved
+// with params. Parts run on an input that is parsed once up front.
+func operation(day int, phase string, data []byte, params Params) (func() error, error) {
+	switch phase {
+	case PhaseParse:
+		return func() error {
+			_, err := parse(day, data, params)
+			return err
+		}, nil
+	case PhasePart1, PhasePart2:
+		part := 1
+		if phase == PhasePart2 {
+			part = 2
+		}
+
+		s, err := parse(day, data, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() error {
+			_, err := Solve(s, part)
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("Unknown phase: %s", phase)
+	}
+}
+
+func Benchmark(day int, phase string, data []byte, params Params) func(b *testing.B) {
+	return func(b *testing.B) {
+		op, err := operation(day, phase, data, params)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i += 1 {
+			if err := op(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// peakHeap runs op once while sampling the heap, and returns the highest heap
+// size seen above the heap in use before it started. Short operations finish
+// between two samples, in which case the heap right after the run counts.
+func peakHeap(op func() error) uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := stats.HeapAlloc
+	peak := baseline
+
+	done := make(chan struct{})
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+
+		var stats runtime.MemStats
+		ticker := time.NewTicker(100 * time.Microsecond)
+		defer ticker.Stop()
+
+		for {
+			runtime.ReadMemStats(&stats)
+			if stats.HeapAlloc > peak {
+				peak = stats.HeapAlloc
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	op()
+
+	close(done)
+	wait.Wait()
+
+	runtime.ReadMemStats(&stats)
+	if stats.HeapAlloc > peak {
+		peak = stats.HeapAlloc
+	}
+
+	return peak - baseline
+}
+
+func Measure(day int, phase string, data []byte, params Params) (Measurement, error) {
+	op, err := operation(day, phase, data, params)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	if err := op(); err != nil {
+		return Measurement{}, err
+	}
+
+	result := testing.Benchmark(Benchmark(day, phase, data, params))
+	if result.N == 0 {
+		return Measurement{}, fmt.Errorf("Day %d %s failed", day, phase)
+	}
+
+	return Measurement{
+		Day:         day,
+		Phase:       phase,
+		NsPerOp:     float64(result.T.Nanoseconds()) / float64(result.N),
+		BytesPerOp:  result.AllocedBytesPerOp(),
+		AllocsPerOp: result.AllocsPerOp(),
+		PeakHeap:    peakHeap(op),
+	}, nil
+}
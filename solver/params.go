@@ -0,0 +1,75 @@
+package solver
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+// Configurable is implemented by solvers with puzzle parameters. Params
+// defines every parameter on the flag set, bound to the solver field it
+// controls, with its default and a description.
+type Configurable interface {
+	Params(params *flag.FlagSet)
+}
+
+// Params maps parameter names to their values in flag syntax.
+type Params map[string]string
+
+func (params *Params) UnmarshalJSON(data []byte) error {
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*params = make(Params)
+	for name, value := range raw {
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			text = string(value)
+		}
+		(*params)[name] = text
+	}
+	return nil
+}
+
+func LoadParams(filename string) (Params, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(Params)
+	if err := json.Unmarshal(bytes, &params); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return params, nil
+}
+
+// ParamSet returns the parameters of solver. Defining them resets every
+// parameter to its default.
+func ParamSet(solver Solver) *flag.FlagSet {
+	params := flag.NewFlagSet("params", flag.ContinueOnError)
+	if configurable, ok := solver.(Configurable); ok {
+		configurable.Params(params)
+	}
+	return params
+}
+
+// Configure resets the parameters of solver to their defaults and then
+// applies params.
+func Configure(solver Solver, params Params) error {
+	set := ParamSet(solver)
+	for name, value := range params {
+		if set.Lookup(name) == nil {
+			return fmt.Errorf("Unknown parameter %q", name)
+		}
+
+		if err := set.Set(name, value); err != nil {
+			return fmt.Errorf("Invalid value %s for parameter %q: %s", strconv.Quote(value), name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package solver
+
+import "time"
+
+type Details map[string]interface{}
+
+// Detailed is implemented by solvers that can explain how they found the
+// answer of a part, such as intermediate values or the positions involved.
+type Detailed interface {
+	Details(part int) Details
+}
+
+type Result struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   Answer        `json:"answer"`
+	Duration time.Duration `json:"duration_ns"`
+	Details  Details       `json:"details,omitempty"`
+	Err      error         `json:"-"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func Run(solver Solver, day, part int) Result {
+	start := time.Now()
+	answer, err := Solve(solver, part)
+
+	result := Result{
+		Day:      day,
+		Part:     part,
+		Answer:   answer,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	} else if detailed, ok := solver.(Detailed); ok {
+		result.Details = detailed.Details(part)
+	}
+
+	return result
+}
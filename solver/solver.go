@@ -0,0 +1,61 @@
+package solver
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+type Answer int64
+
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+type Factory func() Solver
+
+const Parts = 2
+
+var registry = make(map[int]Factory)
+
+func Register(day int, factory Factory) {
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("solver: day %d registered twice", day))
+	}
+
+	registry[day] = factory
+}
+
+func New(day int) (Solver, error) {
+	factory, ok := registry[day]
+	if !ok {
+		return nil, fmt.Errorf("No solver registered for day %d", day)
+	}
+
+	solver := factory()
+	ParamSet(solver)
+	return solver, nil
+}
+
+func Days() []int {
+	days := make([]int, 0, len(registry))
+	for day := range registry {
+		days = append(days, day)
+	}
+
+	sort.Ints(days)
+	return days
+}
+
+func Solve(solver Solver, part int) (Answer, error) {
+	switch part {
+	case 1:
+		return solver.Part1()
+	case 2:
+		return solver.Part2()
+	default:
+		return 0, fmt.Errorf("Unknown part: %d", part)
+	}
+}
@@ -0,0 +1,145 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// Expectation lists the known answers for one input. Parts without a known
+// answer are left out and skipped during verification.
+type Expectation struct {
+	Day    int     `json:"day,omitempty"`
+	Input  string  `json:"input,omitempty"`
+	Params Params  `json:"params,omitempty"`
+	Part1  *Answer `json:"part1,omitempty"`
+	Part2  *Answer `json:"part2,omitempty"`
+}
+
+func (expectation *Expectation) Answer(part int) (Answer, bool) {
+	var answer *Answer
+	switch part {
+	case 1:
+		answer = expectation.Part1
+	case 2:
+		answer = expectation.Part2
+	}
+
+	if answer == nil {
+		return 0, false
+	}
+	return *answer, true
+}
+
+func LoadExpectations(filename string) ([]Expectation, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	expectations := make([]Expectation, 0)
+	if err := json.Unmarshal(bytes, &expectations); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	return expectations, nil
+}
+
+type Status string
+
+const (
+	PASS  Status = "PASS"
+	FAIL  Status = "FAIL"
+	ERROR Status = "ERROR"
+	SKIP  Status = "SKIP"
+)
+
+type Check struct {
+	Day      int
+	Part     int
+	Input    string
+	Expected Answer
+	Actual   Answer
+	Status   Status
+	Err      error
+}
+
+func (check Check) String() string {
+	switch check.Status {
+	case FAIL:
+		return fmt.Sprintf("day %d part %d (%s): expected %d, got %d",
+			check.Day, check.Part, check.Input, check.Expected, check.Actual)
+	case ERROR:
+		return fmt.Sprintf("day %d part %d (%s): %s", check.Day, check.Part, check.Input, check.Err)
+	default:
+		return fmt.Sprintf("day %d part %d (%s): %s", check.Day, check.Part, check.Input, check.Status)
+	}
+}
+
+// Verify solves every part of day for the input file and compares the answers
+// against expectation. It returns one check per part.
+func Verify(day int, filename string, expectation Expectation) []Check {
+	checks := make([]Check, Parts)
+	for ix := range checks {
+		checks[ix] = Check{
+			Day:    day,
+			Part:   ix + 1,
+			Input:  filename,
+			Status: SKIP,
+		}
+		checks[ix].Expected, _ = expectation.Answer(ix + 1)
+	}
+
+	if expectation.Part1 == nil && expectation.Part2 == nil {
+		return checks
+	}
+
+	fail := func(err error) []Check {
+		for ix := range checks {
+			if _, ok := expectation.Answer(ix + 1); ok {
+				checks[ix].Status = ERROR
+				checks[ix].Err = err
+			}
+		}
+		return checks
+	}
+
+	s, err := New(day)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := Configure(s, expectation.Params); err != nil {
+		return fail(err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return fail(err)
+	}
+	defer file.Close()
+
+	if err := s.Parse(file); err != nil {
+		return fail(err)
+	}
+
+	for ix := range checks {
+		expected, ok := expectation.Answer(ix + 1)
+		if !ok {
+			continue
+		}
+
+		checks[ix].Actual, checks[ix].Err = Solve(s, ix+1)
+		switch {
+		case checks[ix].Err != nil:
+			checks[ix].Status = ERROR
+		case checks[ix].Actual != expected:
+			checks[ix].Status = FAIL
+		default:
+			checks[ix].Status = PASS
+		}
+	}
+
+	return checks
+}